@@ -3,13 +3,23 @@
 package config
 
 import (
+	"bytes"
+	"context"
+	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
 	"sync"
 
+	"github.com/next-trace/scg-config/configerrors"
 	"github.com/next-trace/scg-config/contract"
+	"github.com/next-trace/scg-config/dotmap"
 	"github.com/next-trace/scg-config/loader/env"
 	"github.com/next-trace/scg-config/loader/file"
 	"github.com/next-trace/scg-config/provider/viper"
+	"github.com/next-trace/scg-config/utils"
 	"github.com/next-trace/scg-config/watcher"
 )
 
@@ -21,7 +31,20 @@ type Config struct {
 	fileLoader   contract.FileLoader
 	envLoader    contract.EnvLoader
 	watchedFiles map[string]bool
+	sections     map[sectionKey]any
+	types        map[string]contract.KeyType
+	envOverlay   *string
+	onReloadErr  func(error)
+	reloadErr    error
+	initial      []map[string]any
+	transformers []contract.ValueTransformer
+	throttle     reloadThrottle
+	frozen       bool
 	done         chan struct{}
+	changes      chan struct{}
+	bindings     []binding
+	bindMu       sync.Mutex
+	writeMu      sync.Mutex // serializes changes to the provider settings, see update
 	mu           sync.RWMutex
 }
 
@@ -40,8 +63,44 @@ func WithFileLoader(fl contract.FileLoader) Option { return func(c *Config) { c.
 // WithEnvLoader sets a custom EnvLoader implementation on the Config.
 func WithEnvLoader(el contract.EnvLoader) Option { return func(c *Config) { c.envLoader = el } }
 
+// WithEnvOverlay makes Load, LoadKey and ReloadValidated overlay environment
+// variables with the given prefix (e.g. "APP" for APP_SERVER_PORT ->
+// server.port) on top of the provider settings before decoding. This gives the
+// same env precedence for every provider, not only those that read the
+// environment themselves. An empty prefix overlays every environment variable.
+func WithEnvOverlay(prefix string) Option {
+	return func(c *Config) { c.envOverlay = &prefix }
+}
+
+// WithInitialValues deep-merges m into the provider when the Config is
+// constructed, before the first getter snapshot is taken, so the values are
+// visible immediately. Useful for tests and embedded defaults; later sources
+// (files, env, Set) override them as usual. The option may be repeated.
+func WithInitialValues(m map[string]any) Option {
+	return func(c *Config) { c.initial = append(c.initial, m) }
+}
+
+// WithValueTransformer registers a transformer that Get applies to each
+// resolved value before type conversion, e.g. to decrypt secrets. Transformers
+// run in registration order and their errors are returned by Get.
+func WithValueTransformer(t contract.ValueTransformer) Option {
+	return func(c *Config) { c.transformers = append(c.transformers, t) }
+}
+
+// WithReloadErrorHandler sets a function that receives errors from reloads
+// triggered by the watcher (see StartWatching), which otherwise have no caller
+// to return them to. The handler runs on the watcher goroutine.
+func WithReloadErrorHandler(fn func(error)) Option {
+	return func(c *Config) { c.onReloadErr = fn }
+}
+
 // New constructs a Config with optional components. Missing components default
 // to Viper-based provider, file/env loaders, and a file watcher.
+//
+// Passing nil to any With* option, including a typed nil such as
+// (*MyProvider)(nil) wrapped in the interface, is the same as omitting it:
+// the default component is used, so methods such as Close and StartWatching
+// never operate on a nil component.
 func New(opts ...Option) *Config {
 	cfg := &Config{
 		provider:     nil,
@@ -50,30 +109,39 @@ func New(opts ...Option) *Config {
 		fileLoader:   nil,
 		envLoader:    nil,
 		watchedFiles: make(map[string]bool),
+		sections:     make(map[sectionKey]any),
+		types:        make(map[string]contract.KeyType),
 		done:         make(chan struct{}),
+		changes:      make(chan struct{}, 1),
 		mu:           sync.RWMutex{},
 	}
 	for _, opt := range opts {
 		opt(cfg)
 	}
 
-	if cfg.provider == nil {
+	if isNil(cfg.provider) {
 		cfg.provider = viper.NewConfigProvider()
 	}
 
-	if cfg.fileLoader == nil {
+	if isNil(cfg.fileLoader) {
 		cfg.fileLoader = file.NewFileLoader(cfg.provider)
 	}
 
-	if cfg.envLoader == nil {
+	if isNil(cfg.envLoader) {
 		cfg.envLoader = env.NewEnvLoader(cfg.provider)
 	}
 
-	if cfg.watcher == nil {
+	if isNil(cfg.watcher) {
 		cfg.watcher = watcher.NewWatcher(nil)
 	}
+	for _, values := range cfg.initial {
+		// MergeConfigMap only fails for providers that cannot hold maps; New has
+		// no error return, and such a provider would reject any file load too.
+		_ = cfg.provider.MergeConfigMap(values)
+	}
+
 	// Snapshot config map for the getter
-	cfg.getter = NewGetter(cfg.provider.AllSettings())
+	cfg.getter = cfg.newGetter()
 
 	// Set the config reference in the watcher after the config is fully constructed
 	if w, ok := cfg.watcher.(*watcher.Watcher); ok {
@@ -83,19 +151,133 @@ func New(opts ...Option) *Config {
 	return cfg
 }
 
+// isNil reports whether v is nil or an interface holding a nil pointer, map,
+// slice, channel or func.
+func isNil(v any) bool {
+	if v == nil {
+		return true
+	}
+
+	value := reflect.ValueOf(v)
+	switch value.Kind() {
+	case reflect.Ptr, reflect.Map, reflect.Slice, reflect.Chan, reflect.Func, reflect.Interface:
+		return value.IsNil()
+	default:
+		return false
+	}
+}
+
 // Get returns the value associated with key converted to the provided KeyType.
 // It supports both flat lookups and dot-notation for nested structures.
 func (c *Config) Get(key string, typ contract.KeyType) (any, error) {
-	return c.getter.Get(key, typ)
+	return c.snapshot().Get(key, typ)
+}
+
+// Lookup returns the value of key converted to typ and whether the key exists;
+// see Getter.Lookup.
+func (c *Config) Lookup(key string, typ contract.KeyType) (value any, found bool, err error) {
+	return c.snapshot().Lookup(key, typ)
+}
+
+// GetCtx behaves like Get, but when key is missing from the snapshot and the
+// provider implements contract.LazyProvider, the value is fetched on demand
+// with ctx and then transformed and converted like any other value. Keys in
+// the snapshot never reach the provider, so the snapshot stays the fast path.
+// Lazily fetched values are not cached. A failed fetch is returned wrapped;
+// a key the provider does not know returns ErrKeyNotFound.
+func (c *Config) GetCtx(ctx context.Context, key string, typ contract.KeyType) (any, error) {
+	getter := c.snapshot()
+	if getter.HasKey(key) {
+		return getter.Get(key, typ)
+	}
+
+	lazy, ok := c.provider.(contract.LazyProvider)
+	if !ok {
+		return nil, configerrors.ErrKeyNotFound
+	}
+
+	value, found, err := lazy.GetLazy(ctx, key)
+	if err != nil {
+		return nil, fmt.Errorf("config: fetching %q: %w", key, err)
+	}
+
+	if !found {
+		return nil, configerrors.ErrKeyNotFound
+	}
+
+	value, err = getter.transform(key, value)
+	if err != nil {
+		return nil, err
+	}
+
+	value, err = tryTypeCast(value, typ)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", configerrors.ErrWrongType, err)
+	}
+
+	return value, nil
+}
+
+// GetFirst returns the value of the first of keys that exists, converted to
+// typ, together with the key that matched. It supports renaming keys: list the
+// new name first and the deprecated one after it, and log a deprecation
+// warning when the returned key is not the first. ErrKeyNotFound is returned
+// only when none of the keys exist; a conversion error of the matched key is
+// returned as is, without trying the remaining keys.
+func (c *Config) GetFirst(keys []string, typ contract.KeyType) (any, string, error) {
+	getter := c.snapshot()
+
+	for _, key := range keys {
+		if !getter.HasKey(key) {
+			continue
+		}
+
+		value, err := getter.Get(key, typ)
+
+		return value, key, err
+	}
+
+	return nil, "", fmt.Errorf("%w: %s", configerrors.ErrKeyNotFound, strings.Join(keys, ", "))
+}
+
+// GetRawKey returns the untyped value stored at key and whether it exists.
+func (c *Config) GetRawKey(key string) (any, bool) {
+	return c.snapshot().lookup(key)
+}
+
+// Origin returns where the effective value of key came from, e.g.
+// "file:/etc/app.yaml", "env:APP_NAME" or "set". It reports false when the key
+// has no recorded origin or the provider does not implement contract.OriginTracker.
+func (c *Config) Origin(key string) (string, bool) {
+	tracker, ok := c.provider.(contract.OriginTracker)
+	if !ok {
+		return "", false
+	}
+
+	return tracker.Origin(key)
 }
 
 // Has reports whether the given key exists in the configuration.
 func (c *Config) Has(key string) bool {
-	return c.getter.HasKey(key)
+	return c.snapshot().HasKey(key)
+}
+
+// IsSet reports whether key exists, resolving nested dot paths and array
+// indexes regardless of the provider. It is an alias of Has and the canonical
+// existence check; Provider().IsSet is a flat, backend-specific lookup.
+func (c *Config) IsSet(key string) bool {
+	return c.Has(key)
 }
 
 // ReadInConfig asks the Provider to read configuration from its sources.
 func (c *Config) ReadInConfig() error {
+	if err := c.checkFrozen(); err != nil {
+		return err
+	}
+
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+
 	err := c.provider.ReadInConfig()
 	if err != nil {
 		return fmt.Errorf("error reading config: %w", err)
@@ -104,6 +286,13 @@ func (c *Config) ReadInConfig() error {
 	return nil
 }
 
+// ConfigFiles returns the configuration files the settings were read from,
+// in load order, e.g. for logging "loaded config from X, Y" at startup. It
+// reports what the provider knows via Provider.ConfigFilesUsed.
+func (c *Config) ConfigFiles() []string {
+	return c.provider.ConfigFilesUsed()
+}
+
 // WatchFile registers a file path to be tracked by the watcher.
 func (c *Config) WatchFile(filePath string) {
 	c.mu.Lock()
@@ -131,10 +320,19 @@ func (c *Config) WatchedFiles() []string {
 	return files
 }
 
-// StartWatching registers the file with the watcher and begins watching.
+// StartWatching registers the file with the watcher and begins watching. On
+// each change the configuration is re-read and the getter refreshed, but only
+// if the changed file parses: a broken or half-written save keeps the running
+// configuration and is reported to the WithReloadErrorHandler handler. An
+// emptied file counts as half-written; write an empty document such as "{}"
+// to clear it. Use SetReloadThrottle to cap how often such reloads run.
 func (c *Config) StartWatching(filePath string) error {
+	if err := c.checkFrozen(); err != nil {
+		return err
+	}
+
 	err := c.watcher.AddFile(filePath, func() {
-		_ = c.provider.ReadInConfig()
+		c.throttle.do(func() { c.reloadFromFile(filePath) })
 	})
 	if err != nil {
 		return fmt.Errorf("error starting watcher for file %s: %w", filePath, err)
@@ -143,18 +341,149 @@ func (c *Config) StartWatching(filePath string) error {
 	return nil
 }
 
-// Close stops the watcher and releases resources held by the Config.
+// reloadFromFile re-reads the configuration after filePath changed. The file
+// is parsed into a staging provider first so that an invalid file never
+// replaces the live configuration. An empty file is treated as a save in
+// progress (editors and os.WriteFile truncate before writing) and skipped too.
+func (c *Config) reloadFromFile(filePath string) {
+	if c.checkFrozen() != nil {
+		return
+	}
+
+	// #nosec G304 -- filePath was explicitly registered for watching by the caller.
+	data, err := os.ReadFile(filePath)
+	if err == nil && len(bytes.TrimSpace(data)) == 0 {
+		err = fmt.Errorf("%w: %s is empty", configerrors.ErrReadConfigFileFailed, filePath)
+	}
+
+	if err != nil {
+		c.reportReloadError(fmt.Errorf("error reloading %s, keeping previous config: %w", filePath, err))
+
+		return
+	}
+
+	staging := viper.NewConfigProvider()
+	if err := file.NewFileLoader(staging).LoadFromFile(filePath); err != nil {
+		c.reportReloadError(fmt.Errorf("error reloading %s, keeping previous config: %w", filePath, err))
+
+		return
+	}
+
+	err = c.update(func() error {
+		if err := c.provider.ReadInConfig(); err != nil {
+			return fmt.Errorf("error reloading config: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		c.reportReloadError(err)
+
+		return
+	}
+
+	c.mu.Lock()
+	c.reloadErr = nil
+	c.mu.Unlock()
+}
+
+// reportReloadError records err as the last reload error and passes it to the
+// reload error handler, if one is set.
+func (c *Config) reportReloadError(err error) {
+	c.mu.Lock()
+	c.reloadErr = err
+	c.mu.Unlock()
+
+	if c.onReloadErr != nil {
+		c.onReloadErr(err)
+	}
+}
+
+// LastReloadError returns the error of the most recent reload triggered by the
+// watcher (see StartWatching), or nil if that reload succeeded or none has run.
+func (c *Config) LastReloadError() error {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.reloadErr
+}
+
+// StartWatchingContext behaves like StartWatching and additionally closes the
+// watcher once ctx is done.
+func (c *Config) StartWatchingContext(ctx context.Context, filePath string) error {
+	if err := c.StartWatching(filePath); err != nil {
+		return err
+	}
+
+	go func() {
+		select {
+		case <-ctx.Done():
+			_ = c.watcher.Close()
+		case <-c.done:
+		}
+	}()
+
+	return nil
+}
+
+// PauseWatching suspends watcher callbacks, e.g. during a bulk edit of config
+// files. It is a no-op when the watcher does not support pausing.
+func (c *Config) PauseWatching() {
+	if pausable, ok := c.watcher.(interface{ Pause() }); ok {
+		pausable.Pause()
+	}
+}
+
+// ResumeWatching re-enables watcher callbacks; changes made while paused are
+// reported by a single callback. It is a no-op when the watcher does not
+// support pausing.
+func (c *Config) ResumeWatching() {
+	if resumable, ok := c.watcher.(interface{ Resume() }); ok {
+		resumable.Resume()
+	}
+}
+
+// String returns a concise summary of the Config for logging: the number of
+// keys, the number of watched files and the provider and watcher types. It
+// never includes configuration values, so it is safe to log.
+func (c *Config) String() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	keys := 0
+	if c.getter != nil {
+		keys = len(dotmap.Flatten(c.getter.config))
+	}
+
+	return fmt.Sprintf("config.Config{keys: %d, watched files: %d, provider: %T, watcher: %T}",
+		keys, len(c.watchedFiles), c.provider, c.watcher)
+}
+
+// Close stops the watcher, closes the stream of a contract.StreamProvider and
+// the Changes channel, and releases resources held by the Config.
 func (c *Config) Close() error {
 	close(c.done)
+	c.throttle.stop()
+
+	c.mu.Lock()
+	close(c.changes)
+	c.mu.Unlock()
+
+	var errs []error
+
+	if streamer, ok := c.provider.(contract.StreamProvider); ok {
+		if err := streamer.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("error closing stream: %w", err))
+		}
+	}
 
 	if c.watcher != nil {
-		err := c.watcher.Close()
-		if err != nil {
-			return fmt.Errorf("error closing watcher: %w", err)
+		if err := c.watcher.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("error closing watcher: %w", err))
 		}
 	}
 
-	return nil
+	return errors.Join(errs...)
 }
 
 // Provider returns the underlying provider.
@@ -187,15 +516,249 @@ func (c *Config) Watcher() contract.Watcher {
 
 // Reload reloads the configuration from the provider and updates the getter.
 func (c *Config) Reload() error {
-	err := c.provider.ReadInConfig()
+	if err := c.checkFrozen(); err != nil {
+		return err
+	}
+
+	return c.update(func() error {
+		if err := c.provider.ReadInConfig(); err != nil {
+			return fmt.Errorf("error reloading config: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// SetMap deep-merges m into the provider configuration, as if it had been
+// loaded from a file, and refreshes the getter so the changes are visible
+// immediately without re-reading any file. Nested maps are merged key by key;
+// other values replace existing ones.
+func (c *Config) SetMap(m map[string]any) error {
+	if err := c.checkFrozen(); err != nil {
+		return err
+	}
+
+	return c.update(func() error {
+		if err := c.provider.MergeConfigMap(m); err != nil {
+			return fmt.Errorf("error merging config map: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// ReplaceAll swaps the entire configuration for m and refreshes the getter.
+// Unlike SetMap it does not merge: keys absent from m, including values
+// written via Set, are gone afterwards, which suits remote sources that always
+// deliver the whole tree. Readers see either the old or the new configuration,
+// never a mix. The provider must implement contract.Replacer, as the Viper
+// provider does.
+func (c *Config) ReplaceAll(m map[string]any) error {
+	if err := c.checkFrozen(); err != nil {
+		return err
+	}
+
+	return c.update(func() error { return c.replace(m) })
+}
+
+// replace swaps the provider settings for m. The caller must hold writeMu.
+func (c *Config) replace(m map[string]any) error {
+	replacer, ok := c.provider.(contract.Replacer)
+	if !ok {
+		return fmt.Errorf("config: provider %T does not support replacing its settings", c.provider)
+	}
+
+	if err := replacer.Replace(m); err != nil {
+		return fmt.Errorf("error replacing config: %w", err)
+	}
+
+	return nil
+}
+
+// ReloadSection re-reads the section of the configuration under prefix (e.g.
+// "database") from the file at path, parsed according to format (e.g. "yaml";
+// the extension of path when empty), leaving every other key untouched. When
+// the file has a map at prefix, that sub-tree becomes the section; otherwise
+// the whole file is the section. The section is replaced, not merged: keys
+// removed from the file disappear from the configuration. Like ReplaceAll it
+// requires a provider that implements contract.Replacer, and a later Reload
+// that re-reads the provider's own config file restores that file's version of
+// the section.
+func (c *Config) ReloadSection(prefix, path, format string) error {
+	if prefix == "" {
+		return fmt.Errorf("config: section prefix is empty")
+	}
+
+	if format == "" {
+		format = filepath.Ext(path)
+	}
+
+	// #nosec G304 -- path is an explicit section file chosen by the caller.
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("%w: %w", configerrors.ErrReadConfigFileFailed, err)
+	}
+
+	staging := viper.NewConfigProvider()
+	if err := file.NewFileLoader(staging).MergeFromReader(bytes.NewReader(data), format); err != nil {
+		return fmt.Errorf("error reloading section %s from %s: %w", prefix, path, err)
+	}
+
+	parsed := staging.Snapshot()
+
+	section, err := utils.ToMap(dotmap.Resolve(parsed, prefix))
 	if err != nil {
-		return fmt.Errorf("error reloading config: %w", err)
+		section = parsed
 	}
 
-	c.getter = NewGetter(c.provider.AllSettings())
+	if err := c.checkFrozen(); err != nil {
+		return err
+	}
+
+	return c.update(func() error {
+		return c.replace(setPath(c.provider.Snapshot(), strings.Split(strings.ToLower(prefix), "."), section))
+	})
+}
+
+// MergeProvider deep-merges every setting of other into the provider, with the
+// same semantics as SetMap, and refreshes the getter. This layers a second
+// source, e.g. values fetched from a remote store, over the ones already
+// loaded. other is read through Snapshot, so later changes to it are not
+// picked up; call MergeProvider again to apply them.
+func (c *Config) MergeProvider(other contract.Provider) error {
+	if isNil(other) {
+		return fmt.Errorf("config: provider to merge is nil")
+	}
+
+	return c.SetMap(other.Snapshot())
+}
+
+// ReloadValidated re-reads configuration into a staging snapshot, decodes and
+// validates it into out (a non-nil pointer, typically to a struct) and only then
+// swaps the live getter. If reading or validation fails, the error is returned,
+// out is left untouched and the previous good configuration is kept: the getter
+// is not swapped and the provider's previous settings are restored as a whole,
+// as in ReplaceAll, so keys only the rejected sources added are gone again.
+// Providers that do not implement contract.Replacer get the previous settings
+// merged back instead.
+func (c *Config) ReloadValidated(out any) error {
+	target := reflect.ValueOf(out)
+	if out == nil || target.Kind() != reflect.Pointer || target.IsNil() {
+		return fmt.Errorf("config: output target must be a non-nil pointer")
+	}
+
+	if err := c.checkFrozen(); err != nil {
+		return err
+	}
+
+	return c.update(func() error {
+		previous := c.provider.Snapshot()
+
+		if err := c.provider.ReadInConfig(); err != nil {
+			return fmt.Errorf("error reloading config: %w", err)
+		}
+
+		staging := reflect.New(target.Elem().Type())
+		if err := decodeAndValidate(c.loadSettings(), staging.Interface(), ""); err != nil {
+			if restoreErr := c.restore(previous); restoreErr != nil {
+				return fmt.Errorf("%w (restoring previous config failed: %w)", err, restoreErr)
+			}
+
+			return err
+		}
+
+		target.Elem().Set(staging.Elem())
+
+		return nil
+	})
+}
+
+// restore puts previous settings back after a rejected reload, replacing the
+// current ones when the provider supports it. The caller must hold writeMu.
+func (c *Config) restore(previous map[string]any) error {
+	if _, ok := c.provider.(contract.Replacer); !ok {
+		return c.provider.MergeConfigMap(previous)
+	}
+
+	return c.replace(previous)
+}
+
+// update runs change, which modifies the provider settings, and refreshes the
+// getter when it succeeds. Every write path goes through update: writeMu is
+// held from the first provider read inside change until the getter is swapped,
+// so a read-modify-replace such as Transaction never loses a concurrent
+// change. Bound targets are re-decoded after writeMu is released, since doing
+// so may call the reload error handler.
+func (c *Config) update(change func() error) error {
+	c.writeMu.Lock()
+
+	if err := change(); err != nil {
+		c.writeMu.Unlock()
+
+		return err
+	}
+
+	c.swapGetter()
+	c.writeMu.Unlock()
+
+	c.rebind()
 
 	return nil
 }
 
+// swapGetter rebuilds the getter snapshot from the provider and clears cached
+// sections, without re-reading configuration sources.
+func (c *Config) swapGetter() {
+	getter := c.newGetter()
+
+	c.mu.Lock()
+	c.getter = getter
+	c.sections = make(map[sectionKey]any)
+	c.notifyChange()
+	c.mu.Unlock()
+}
+
+// Changes returns a channel that receives a value after every refresh of the
+// configuration: reloads triggered by the watcher, a signal or a stream push,
+// as well as Reload, SetMap and the other methods that change settings. The
+// channel buffers a single pending notification and further changes coalesce
+// into it, so a slow or absent reader never blocks a reload; re-read the
+// values you need after each receive. The channel is closed by Close.
+func (c *Config) Changes() <-chan struct{} {
+	return c.changes
+}
+
+// notifyChange signals Changes without blocking. The caller must hold c.mu,
+// which orders it with Close closing the channel.
+func (c *Config) notifyChange() {
+	select {
+	case <-c.done:
+		return
+	default:
+	}
+
+	select {
+	case c.changes <- struct{}{}:
+	default:
+	}
+}
+
+// newGetter builds a getter over a deep copy of the current provider settings,
+// so neither side can observe the other's mutations.
+func (c *Config) newGetter() *Getter {
+	getter := newOwnedGetter(c.provider.Snapshot())
+	getter.transformers = c.transformers
+
+	return getter
+}
+
+// snapshot returns the current getter.
+func (c *Config) snapshot() *Getter {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.getter
+}
+
 // --- Interface assertion: only ValueAccessor, not ValueReader! ---.
 var _ contract.Config = (*Config)(nil)
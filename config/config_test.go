@@ -1,17 +1,25 @@
 package config_test
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/next-trace/scg-config/config"
+	"github.com/next-trace/scg-config/configerrors"
 	"github.com/next-trace/scg-config/contract"
+	"github.com/next-trace/scg-config/loader/file"
 	"github.com/next-trace/scg-config/provider/viper"
+	"github.com/next-trace/scg-config/watcher/poll"
 )
 
 func TestConfig_Get(t *testing.T) {
@@ -92,11 +100,13 @@ type fakeProvider struct {
 
 func (f *fakeProvider) ReadInConfig() error                 { return f.readE }
 func (f *fakeProvider) AllSettings() map[string]interface{} { return f.all }
+func (f *fakeProvider) Snapshot() map[string]interface{}    { return f.all }
 func (f *fakeProvider) GetKey(key string) any               { return f.all[key] }
 func (f *fakeProvider) Set(key string, value any)           { f.all[key] = value }
 func (f *fakeProvider) IsSet(key string) bool               { _, ok := f.all[key]; return ok }
 func (f *fakeProvider) Provider() any                       { return nil }
 func (f *fakeProvider) SetConfigFile(string)                {}
+func (f *fakeProvider) ConfigFilesUsed() []string           { return nil }
 func (f *fakeProvider) MergeConfigMap(cfg map[string]interface{}) error {
 	for k, v := range cfg {
 		f.all[k] = v
@@ -188,3 +198,632 @@ func TestConfig_ReadInConfig_Success(t *testing.T) {
 	cfg.Provider().SetConfigFile(path)
 	require.NoError(t, cfg.ReadInConfig())
 }
+
+type closeSignalWatcher struct {
+	closed chan struct{}
+	once   sync.Once
+}
+
+func (w *closeSignalWatcher) AddFile(string, func()) error { return nil }
+func (w *closeSignalWatcher) Watch(func())                 {}
+func (w *closeSignalWatcher) Close() error {
+	w.once.Do(func() { close(w.closed) })
+	return nil
+}
+
+func TestConfig_StartWatchingContext_ClosesOnCancel(t *testing.T) {
+	t.Parallel()
+	w := &closeSignalWatcher{closed: make(chan struct{})}
+	cfg := config.New(config.WithProvider(&fakeProvider{all: map[string]any{}}), config.WithWatcher(w))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	require.NoError(t, cfg.StartWatchingContext(ctx, "/tmp/file.yaml"))
+	cancel()
+
+	select {
+	case <-w.closed:
+	case <-time.After(2 * time.Second):
+		t.Fatal("watcher was not closed after context cancellation")
+	}
+}
+
+func TestConfig_StartWatchingContext_AddError(t *testing.T) {
+	t.Parallel()
+	w := &fakeWatcher{addErr: errors.New("nope")}
+	cfg := config.New(config.WithProvider(&fakeProvider{all: map[string]any{}}), config.WithWatcher(w))
+	require.Error(t, cfg.StartWatchingContext(context.Background(), "/tmp/file.yaml"))
+}
+
+func TestConfig_GetRawKey(t *testing.T) {
+	t.Parallel()
+	prov := &fakeProvider{all: map[string]any{"server": map[string]any{"port": 8080}}}
+	cfg := config.New(config.WithProvider(prov))
+
+	v, ok := cfg.GetRawKey("server.port")
+	require.True(t, ok)
+	require.Equal(t, 8080, v)
+
+	v, ok = cfg.GetRawKey("server.host")
+	require.False(t, ok)
+	require.Nil(t, v)
+}
+
+func TestConfig_Origin(t *testing.T) {
+	// Note: Cannot use t.Parallel() with t.Setenv()
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("app:\n  name: file\n  port: 80\n  log: info\n"), 0o600))
+	merged := filepath.Join(dir, "db.json")
+	require.NoError(t, os.WriteFile(merged, []byte(`{"db": {"host": "h"}}`), 0o600))
+
+	t.Setenv("ORIGINTEST_APP_NAME", "env")
+	t.Setenv("APP_LOG", "debug")
+
+	cfg := config.New()
+	require.NoError(t, cfg.FileLoader().LoadFromDirectory(dir))
+	require.NoError(t, cfg.EnvLoader().LoadFromEnv("ORIGINTEST"))
+	cfg.Provider().Set("app.port", 8080)
+
+	tests := []struct {
+		key  string
+		want string
+	}{
+		{"app.name", "env:ORIGINTEST_APP_NAME"},
+		{"app.port", "set"},
+		{"app.log", "env:APP_LOG"},
+		{"db.host", "file:" + merged},
+	}
+	for _, tc := range tests {
+		got, ok := cfg.Origin(tc.key)
+		require.True(t, ok, tc.key)
+		require.Equal(t, tc.want, got, tc.key)
+	}
+
+	_, ok := cfg.Origin("missing.key")
+	require.False(t, ok)
+}
+
+func TestConfig_Origin_FileOnly(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "app.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("originfile:\n  name: file\n"), 0o600))
+
+	cfg := config.New()
+	require.NoError(t, cfg.FileLoader().LoadFromFile(path))
+
+	got, ok := cfg.Origin("originfile.name")
+	require.True(t, ok)
+	require.Equal(t, "file:"+path, got)
+}
+
+func TestConfig_Origin_UntrackedProvider(t *testing.T) {
+	t.Parallel()
+	cfg := config.New(config.WithProvider(&fakeProvider{all: map[string]any{"k": "v"}}))
+	_, ok := cfg.Origin("k")
+	require.False(t, ok)
+}
+
+func TestConfig_PauseResumeWatching(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "app.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("app:\n  name: A\n"), 0o600))
+
+	cfg := config.New()
+	defer func() { _ = cfg.Close() }()
+
+	require.NoError(t, cfg.FileLoader().LoadFromFile(path))
+
+	reloaded := make(chan struct{}, 4)
+	require.NoError(t, cfg.Watcher().AddFile(path, func() { reloaded <- struct{}{} }))
+
+	cfg.PauseWatching()
+	require.NoError(t, os.WriteFile(path, []byte("app:\n  name: B\n"), 0o600))
+
+	select {
+	case <-reloaded:
+		t.Fatal("callback fired while paused")
+	case <-time.After(300 * time.Millisecond):
+	}
+
+	cfg.ResumeWatching()
+
+	select {
+	case <-reloaded:
+	default:
+		t.Fatal("expected a callback on resume")
+	}
+
+	// Watchers without pause support are left alone.
+	other := config.New(config.WithWatcher(&fakeWatcher{}))
+	other.PauseWatching()
+	other.ResumeWatching()
+}
+
+func TestConfig_New_TypedNilComponentsUseDefaults(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "app.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("app:\n  name: X\n"), 0o600))
+
+	var (
+		prov    *fakeProvider
+		watcher *fakeWatcher
+	)
+
+	cfg := config.New(
+		config.WithProvider(prov),
+		config.WithWatcher(watcher),
+		config.WithFileLoader(nil),
+		config.WithEnvLoader(nil),
+	)
+
+	require.NotNil(t, cfg.Provider())
+	require.NoError(t, cfg.FileLoader().LoadFromFile(path))
+	require.NoError(t, cfg.Reload())
+	require.True(t, cfg.Has("app.name"))
+	require.NoError(t, cfg.StartWatching(path))
+	require.NoError(t, cfg.Close())
+}
+
+func TestConfig_String_SummarizesWithoutValues(t *testing.T) {
+	t.Parallel()
+	prov := viper.NewConfigProvider()
+	prov.Set("database.password", "s3cr3t")
+	prov.Set("database.host", "db")
+	prov.Set("app.name", "demo")
+
+	cfg := config.New(config.WithProvider(prov), config.WithWatcher(&fakeWatcher{}))
+	cfg.WatchFile("/etc/app.yaml")
+
+	summary := cfg.String()
+	assert.Equal(t,
+		"config.Config{keys: 3, watched files: 1, provider: *viper.ConfigProvider, watcher: *config_test.fakeWatcher}",
+		summary)
+	assert.NotContains(t, summary, "s3cr3t")
+	assert.Equal(t, summary, fmt.Sprintf("%v", cfg))
+}
+
+func TestConfig_SetMap_DeepMerges(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "app.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("database:\n  host: db\n  port: 5432\napp:\n  name: demo\n"), 0o600))
+
+	cfg := config.New()
+	require.NoError(t, cfg.FileLoader().LoadFromFile(path))
+
+	require.NoError(t, cfg.SetMap(map[string]any{
+		"database": map[string]any{"port": 6432, "user": "admin"},
+		"feature":  map[string]any{"beta": true},
+	}))
+
+	// Without a Reload the getter already reflects the merge.
+	host, err := cfg.Get("database.host", contract.String)
+	require.NoError(t, err)
+	assert.Equal(t, "db", host)
+
+	port, err := cfg.Get("database.port", contract.Int)
+	require.NoError(t, err)
+	assert.Equal(t, 6432, port)
+
+	user, err := cfg.Get("database.user", contract.String)
+	require.NoError(t, err)
+	assert.Equal(t, "admin", user)
+
+	beta, err := cfg.Get("feature.beta", contract.Bool)
+	require.NoError(t, err)
+	assert.Equal(t, true, beta)
+	assert.True(t, cfg.Has("app.name"))
+}
+
+func TestConfig_Changes_DeliveredAfterFileEdit(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "app.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("app:\n  name: Before\n"), 0o600))
+
+	cfg := config.New()
+	require.NoError(t, cfg.FileLoader().LoadFromFile(path))
+	require.NoError(t, cfg.Reload())
+
+	// Changes made while nobody reads coalesce into one pending notification
+	// and never block.
+	require.NoError(t, cfg.Reload())
+	<-cfg.Changes()
+
+	require.NoError(t, cfg.StartWatching(path))
+	require.NoError(t, os.WriteFile(path, []byte("app:\n  name: After\n"), 0o600))
+
+	select {
+	case <-cfg.Changes():
+	case <-time.After(2 * time.Second):
+		t.Fatal("no change delivered after the file edit")
+	}
+
+	require.Eventually(t, func() bool {
+		name, err := cfg.Get("app.name", contract.String)
+
+		return err == nil && name == "After"
+	}, 2*time.Second, 20*time.Millisecond)
+
+	// Close closes the channel once any pending notification is drained.
+	require.NoError(t, cfg.Close())
+	require.Eventually(t, func() bool {
+		_, ok := <-cfg.Changes()
+
+		return !ok
+	}, time.Second, time.Millisecond)
+}
+
+func TestConfig_StartWatching_BrokenSaveKeepsConfig(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "app.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("app:\n  name: Stable\n"), 0o600))
+
+	reloadErrs := make(chan error, 8)
+	cfg := config.New(config.WithReloadErrorHandler(func(err error) { reloadErrs <- err }))
+	defer func() { _ = cfg.Close() }()
+
+	require.NoError(t, cfg.FileLoader().LoadFromFile(path))
+	require.NoError(t, cfg.Reload())
+	require.NoError(t, cfg.StartWatching(path))
+
+	// A mid-edit save leaves the file syntactically broken.
+	require.NoError(t, os.WriteFile(path, []byte("app:\n  name: [unclosed\n"), 0o600))
+
+	select {
+	case err := <-reloadErrs:
+		require.Error(t, err)
+	case <-time.After(2 * time.Second):
+		t.Fatal("reload error was not reported")
+	}
+
+	name, err := cfg.Get("app.name", contract.String)
+	require.NoError(t, err)
+	assert.Equal(t, "Stable", name)
+	assert.Equal(t, "Stable", cfg.Provider().GetKey("app.name"))
+
+	// Fixing the file applies the new value without an explicit Reload.
+	require.NoError(t, os.WriteFile(path, []byte("app:\n  name: Fixed\n"), 0o600))
+	require.Eventually(t, func() bool {
+		value, getErr := cfg.Get("app.name", contract.String)
+
+		return getErr == nil && value == "Fixed"
+	}, 2*time.Second, 20*time.Millisecond)
+}
+
+func TestConfig_LastReloadError(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "app.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("app:\n  name: One\n"), 0o600))
+
+	cfg := config.New()
+	defer func() { _ = cfg.Close() }()
+
+	require.NoError(t, cfg.FileLoader().LoadFromFile(path))
+	require.NoError(t, cfg.StartWatching(path))
+	require.NoError(t, cfg.LastReloadError())
+
+	require.NoError(t, os.WriteFile(path, []byte("app: {broken\n"), 0o600))
+	require.Eventually(t, func() bool { return cfg.LastReloadError() != nil }, 2*time.Second, 20*time.Millisecond)
+
+	// A later successful reload clears the error.
+	require.NoError(t, os.WriteFile(path, []byte("app:\n  name: Two\n"), 0o600))
+	require.Eventually(t, func() bool { return cfg.LastReloadError() == nil }, 2*time.Second, 20*time.Millisecond)
+	require.True(t, cfg.Has("app.name"))
+}
+
+func TestConfig_EmptiedFile_KeepsPreviousConfig(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "app.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("app:\n  name: Stable\n"), 0o600))
+
+	// The poll watcher reports the truncation itself, unlike the fsnotify
+	// watcher, which ignores empty content.
+	cfg := config.New(config.WithWatcher(poll.NewWatcher(10 * time.Millisecond)))
+	defer func() { _ = cfg.Close() }()
+
+	require.NoError(t, cfg.FileLoader().LoadFromFile(path))
+	require.NoError(t, cfg.StartWatching(path))
+
+	require.NoError(t, os.WriteFile(path, nil, 0o600))
+	require.Eventually(t, func() bool {
+		return errors.Is(cfg.LastReloadError(), configerrors.ErrReadConfigFileFailed)
+	}, 2*time.Second, 20*time.Millisecond)
+	assert.Equal(t, "Stable", cfg.Provider().GetKey("app.name"))
+
+	// An empty document clears the file deliberately.
+	require.NoError(t, os.WriteFile(path, []byte("{}\n"), 0o600))
+	require.Eventually(t, func() bool { return cfg.LastReloadError() == nil }, 2*time.Second, 20*time.Millisecond)
+}
+
+func TestConfig_IsSet_ResolvesNestedKeys(t *testing.T) {
+	t.Parallel()
+	prov := &fakeProvider{all: map[string]any{
+		"database": map[string]any{"replicas": []any{map[string]any{"host": "r1"}}},
+	}}
+	cfg := config.New(config.WithProvider(prov))
+
+	assert.True(t, cfg.IsSet("database.replicas.0.host"))
+	assert.True(t, cfg.IsSet("database"))
+	assert.False(t, cfg.IsSet("database.replicas.1.host"))
+	assert.False(t, cfg.IsSet("missing"))
+
+	// The fake provider's IsSet is flat only, unlike Config.IsSet.
+	assert.False(t, cfg.Provider().IsSet("database.replicas.0.host"))
+}
+
+func TestConfig_WithInitialValues(t *testing.T) {
+	t.Parallel()
+	cfg := config.New(
+		config.WithInitialValues(map[string]any{
+			"server": map[string]any{"port": 8080, "host": "localhost"},
+		}),
+		config.WithInitialValues(map[string]any{
+			"server": map[string]any{"port": 9090},
+		}),
+	)
+
+	port, err := cfg.Get("server.port", contract.Int)
+	require.NoError(t, err)
+	assert.Equal(t, 9090, port)
+
+	host, err := cfg.Get("server.host", contract.String)
+	require.NoError(t, err)
+	assert.Equal(t, "localhost", host)
+
+	// Runtime overrides still take precedence.
+	cfg.Provider().Set("server.port", 7070)
+	require.NoError(t, cfg.Reload())
+	port, err = cfg.Get("server.port", contract.Int)
+	require.NoError(t, err)
+	assert.Equal(t, 7070, port)
+}
+
+type countingProvider struct {
+	*viper.ConfigProvider
+	reads atomic.Int32
+}
+
+func (p *countingProvider) ReadInConfig() error {
+	p.reads.Add(1)
+
+	return p.ConfigProvider.ReadInConfig()
+}
+
+func TestConfig_SetReloadThrottle_CoalescesTriggers(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("a: 1\n"), 0o600))
+
+	prov := &countingProvider{ConfigProvider: viper.NewConfigProvider()}
+	w := &fakeWatcher{}
+	cfg := config.New(config.WithProvider(prov), config.WithWatcher(w))
+	require.NoError(t, cfg.FileLoader().LoadFromFile(path))
+	require.NoError(t, cfg.StartWatching(path))
+	cfg.SetReloadThrottle(200 * time.Millisecond)
+	base := prov.reads.Load()
+
+	for i := range 20 {
+		require.NoError(t, os.WriteFile(path, fmt.Appendf(nil, "a: %d\n", i+2), 0o600))
+		w.callback()
+	}
+
+	// The first trigger reloads at once; the rest collapse into one trailing reload.
+	assert.Equal(t, base+1, prov.reads.Load())
+	require.Eventually(t, func() bool { return prov.reads.Load() == base+2 }, 2*time.Second, 10*time.Millisecond)
+	time.Sleep(300 * time.Millisecond)
+	assert.Equal(t, base+2, prov.reads.Load())
+
+	value, err := cfg.Get("a", contract.Int)
+	require.NoError(t, err)
+	assert.Equal(t, 21, value)
+	require.NoError(t, cfg.Close())
+}
+
+func TestConfig_GetterDoesNotAliasProvider(t *testing.T) {
+	t.Parallel()
+	cfg := config.New(config.WithInitialValues(map[string]any{
+		"db": map[string]any{"host": "localhost", "replicas": []any{"r1"}},
+	}))
+
+	// AllSettings shares storage with the provider, unlike Snapshot, so this
+	// mutates values the provider holds.
+	settings := cfg.Provider().AllSettings()
+	db, ok := settings["db"].(map[string]any)
+	require.True(t, ok)
+	db["host"] = "mutated"
+	db["replicas"].([]any)[0] = "mutated"
+
+	host, err := cfg.Get("db.host", contract.String)
+	require.NoError(t, err)
+	assert.Equal(t, "localhost", host)
+	replicas, err := cfg.Get("db.replicas", contract.StringSlice)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"r1"}, replicas)
+}
+
+func TestConfig_MergeProvider(t *testing.T) {
+	t.Parallel()
+	cfg := config.New(config.WithInitialValues(map[string]any{
+		"db":  map[string]any{"host": "localhost", "port": 5432},
+		"app": "svc",
+	}))
+
+	remote := &fakeProvider{all: map[string]any{
+		"db":      map[string]any{"host": "db.remote"},
+		"feature": map[string]any{"beta": true},
+	}}
+	require.NoError(t, cfg.MergeProvider(remote))
+
+	host, err := cfg.Get("db.host", contract.String)
+	require.NoError(t, err)
+	assert.Equal(t, "db.remote", host)
+
+	port, err := cfg.Get("db.port", contract.Int)
+	require.NoError(t, err)
+	assert.Equal(t, 5432, port)
+
+	beta, err := cfg.Get("feature.beta", contract.Bool)
+	require.NoError(t, err)
+	assert.Equal(t, true, beta)
+	assert.True(t, cfg.Has("app"))
+
+	require.Error(t, cfg.MergeProvider(nil))
+}
+
+func TestConfig_ReplaceAll(t *testing.T) {
+	t.Parallel()
+	cfg := config.New(config.WithInitialValues(map[string]any{
+		"db":     map[string]any{"host": "localhost", "port": 5432},
+		"legacy": true,
+	}))
+	cfg.Provider().Set("override", "set")
+
+	require.NoError(t, cfg.ReplaceAll(map[string]any{"db": map[string]any{"host": "db.new"}}))
+
+	host, err := cfg.Get("db.host", contract.String)
+	require.NoError(t, err)
+	assert.Equal(t, "db.new", host)
+	assert.False(t, cfg.Has("db.port"))
+	assert.False(t, cfg.Has("legacy"))
+	assert.False(t, cfg.Has("override"))
+	assert.Nil(t, cfg.Provider().GetKey("legacy"))
+
+	unsupported := config.New(config.WithProvider(&fakeProvider{all: map[string]any{}}))
+	require.Error(t, unsupported.ReplaceAll(map[string]any{"a": 1}))
+}
+
+func TestConfig_ReloadSection(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	cfg := config.New(config.WithInitialValues(map[string]any{
+		"database": map[string]any{"host": "db.old", "legacy": true},
+		"app":      map[string]any{"name": "demo"},
+	}))
+
+	// A file holding the section under its own key.
+	nested := filepath.Join(dir, "database.yaml")
+	require.NoError(t, os.WriteFile(nested, []byte("database:\n  host: db.new\n  port: 5432\n"), 0o600))
+	require.NoError(t, cfg.ReloadSection("database", nested, ""))
+
+	host, err := cfg.Get("database.host", contract.String)
+	require.NoError(t, err)
+	assert.Equal(t, "db.new", host)
+	assert.True(t, cfg.Has("database.port"))
+	assert.False(t, cfg.Has("database.legacy"), "keys removed from the section file are removed")
+	assert.True(t, cfg.Has("app.name"), "other sections are untouched")
+
+	// A file that is the section itself.
+	bare := filepath.Join(dir, "db.json")
+	require.NoError(t, os.WriteFile(bare, []byte(`{"host": "db.bare"}`), 0o600))
+	require.NoError(t, cfg.ReloadSection("database", bare, "json"))
+
+	host, err = cfg.Get("database.host", contract.String)
+	require.NoError(t, err)
+	assert.Equal(t, "db.bare", host)
+	assert.False(t, cfg.Has("database.port"))
+	assert.True(t, cfg.Has("app.name"))
+
+	require.ErrorIs(t, cfg.ReloadSection("database", filepath.Join(dir, "missing.yaml"), ""), configerrors.ErrReadConfigFileFailed)
+	require.Error(t, cfg.ReloadSection("", bare, ""))
+}
+
+func TestConfig_ConfigFiles(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "base.yaml"), []byte("a: 1\n"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "local.yaml"), []byte("b: 2\n"), 0o600))
+
+	cfg := config.New()
+	require.Empty(t, cfg.ConfigFiles())
+	require.NoError(t, cfg.FileLoader().LoadFromDirectory(dir))
+	require.Equal(t, []string{filepath.Join(dir, "base.yaml"), filepath.Join(dir, "local.yaml")}, cfg.ConfigFiles())
+}
+
+type lazyProvider struct {
+	fakeProvider
+	remote  map[string]any
+	fetched []string
+	err     error
+}
+
+func (p *lazyProvider) GetLazy(ctx context.Context, key string) (any, bool, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, false, err
+	}
+
+	p.fetched = append(p.fetched, key)
+	if p.err != nil {
+		return nil, false, p.err
+	}
+
+	value, ok := p.remote[key]
+
+	return value, ok, nil
+}
+
+func TestConfig_GetCtx_LazyFallback(t *testing.T) {
+	t.Parallel()
+	prov := &lazyProvider{
+		fakeProvider: fakeProvider{all: map[string]any{"app": map[string]any{"name": "svc"}}},
+		remote:       map[string]any{"tenants.acme.limit": "100"},
+	}
+	cfg := config.New(config.WithProvider(prov))
+	ctx := context.Background()
+
+	name, err := cfg.GetCtx(ctx, "app.name", contract.String)
+	require.NoError(t, err)
+	assert.Equal(t, "svc", name)
+	assert.Empty(t, prov.fetched, "snapshot hits must not reach the provider")
+
+	limit, err := cfg.GetCtx(ctx, "tenants.acme.limit", contract.Int)
+	require.NoError(t, err)
+	assert.Equal(t, 100, limit)
+	assert.Equal(t, []string{"tenants.acme.limit"}, prov.fetched)
+
+	_, err = cfg.GetCtx(ctx, "tenants.other.limit", contract.Int)
+	require.ErrorIs(t, err, configerrors.ErrKeyNotFound)
+
+	_, err = cfg.GetCtx(ctx, "tenants.acme.limit", contract.Bool)
+	require.ErrorIs(t, err, configerrors.ErrWrongType)
+
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	_, err = cfg.GetCtx(canceled, "tenants.acme.limit", contract.Int)
+	require.ErrorIs(t, err, context.Canceled)
+
+	prov.err = errors.New("remote down")
+	_, err = cfg.GetCtx(ctx, "tenants.acme.limit", contract.Int)
+	require.ErrorContains(t, err, "remote down")
+
+	plain := config.New(config.WithProvider(&fakeProvider{all: map[string]any{}}))
+	_, err = plain.GetCtx(ctx, "tenants.acme.limit", contract.Int)
+	require.ErrorIs(t, err, configerrors.ErrKeyNotFound)
+}
+
+func TestConfig_JSONLargeIntegersStayExact(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	const big = int64(1)<<53 + 1 // not representable as float64
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a.json"),
+		[]byte(`{"ids": {"first": 9007199254740993, "ratio": 0.25}}`), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "b.json"),
+		[]byte(`{"ids": {"second": 9007199254740995}}`), 0o600))
+
+	prov := viper.NewConfigProvider()
+	require.NoError(t, file.NewFileLoader(prov).LoadFromDirectory(dir))
+	cfg := config.New(config.WithProvider(prov))
+
+	first, err := cfg.Get("ids.first", contract.Int64)
+	require.NoError(t, err)
+	assert.Equal(t, big, first)
+
+	second, err := cfg.Get("ids.second", contract.Int64)
+	require.NoError(t, err)
+	assert.Equal(t, big+2, second)
+
+	ratio, err := cfg.Get("ids.ratio", contract.Float64)
+	require.NoError(t, err)
+	assert.InDelta(t, 0.25, ratio, 0)
+}
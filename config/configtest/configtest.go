@@ -0,0 +1,118 @@
+package configtest
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/next-trace/scg-config/config"
+	"github.com/next-trace/scg-config/provider/viper"
+	"github.com/next-trace/scg-config/watcher/noop"
+)
+
+// NewForTest returns a Config holding m in a fresh in-memory provider, with a
+// no-op watcher so no files are watched. m is deep-copied, so neither the test
+// nor the Config observes the other's later changes, and dotted keys are
+// expanded to nested form: {"db.host": "x"} is the same as
+// {"db": {"host": "x"}}. Unlike the default provider, the provider ignores
+// environment variables, so the process environment never overrides m.
+//
+//	cfg := configtest.NewForTest(map[string]any{"server.port": 8080})
+func NewForTest(m map[string]any) *config.Config {
+	return config.New(
+		config.WithProvider(viper.NewConfigProvider(viper.WithoutAutomaticEnv())),
+		config.WithWatcher(noop.New()),
+		config.WithInitialValues(expand(m)),
+	)
+}
+
+// expand returns a deep copy of m in which every dotted key, at any depth, is
+// split into nested maps. Maps reached through the same path are merged.
+func expand(m map[string]any) map[string]any {
+	result := make(map[string]any, len(m))
+	expandInto(result, m)
+
+	return result
+}
+
+// expandInto copies the entries of src into dst, expanding dotted keys.
+func expandInto(dst, src map[string]any) {
+	for key, value := range src {
+		parts := strings.Split(key, ".")
+
+		parent := dst
+		for _, part := range parts[:len(parts)-1] {
+			parent = child(parent, part)
+		}
+
+		leaf := parts[len(parts)-1]
+
+		if nested, ok := value.(map[string]any); ok {
+			expandInto(child(parent, leaf), nested)
+
+			continue
+		}
+
+		parent[leaf] = deepCopy(value)
+	}
+}
+
+// child returns the map stored at key in parent, creating it when missing or
+// not a map.
+func child(parent map[string]any, key string) map[string]any {
+	if existing, ok := parent[key].(map[string]any); ok {
+		return existing
+	}
+
+	created := make(map[string]any)
+	parent[key] = created
+
+	return created
+}
+
+// deepCopy returns a copy of v in which every map and slice, at any depth, is
+// newly allocated. Nested map[string]any values are expanded like the top
+// level. Other values are returned as is.
+func deepCopy(v any) any {
+	if nested, ok := v.(map[string]any); ok {
+		return expand(nested)
+	}
+
+	value := reflect.ValueOf(v)
+
+	switch value.Kind() {
+	case reflect.Map:
+		if value.IsNil() {
+			return v
+		}
+
+		result := reflect.MakeMapWithSize(value.Type(), value.Len())
+		for iter := value.MapRange(); iter.Next(); {
+			result.SetMapIndex(iter.Key(), copyElem(iter.Value(), value.Type().Elem()))
+		}
+
+		return result.Interface()
+	case reflect.Slice:
+		if value.IsNil() {
+			return v
+		}
+
+		result := reflect.MakeSlice(value.Type(), value.Len(), value.Len())
+		for i := range value.Len() {
+			result.Index(i).Set(copyElem(value.Index(i), value.Type().Elem()))
+		}
+
+		return result.Interface()
+	default:
+		return v
+	}
+}
+
+// copyElem deep-copies a map or slice element of type typ.
+func copyElem(elem reflect.Value, typ reflect.Type) reflect.Value {
+	copied := deepCopy(elem.Interface())
+	if copied == nil {
+		return reflect.Zero(typ)
+	}
+
+	return reflect.ValueOf(copied).Convert(typ)
+}
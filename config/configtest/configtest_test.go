@@ -0,0 +1,80 @@
+package configtest_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/next-trace/scg-config/config/configtest"
+	"github.com/next-trace/scg-config/contract"
+)
+
+func TestNewForTest_ExpandsDottedKeys(t *testing.T) {
+	t.Parallel()
+	cfg := configtest.NewForTest(map[string]any{
+		"server.port": 8080,
+		"server":      map[string]any{"host": "localhost", "tls.enabled": true},
+		"app":         map[string]any{"name": "demo"},
+	})
+	t.Cleanup(func() { _ = cfg.Close() })
+
+	port, err := cfg.Get("server.port", contract.Int)
+	require.NoError(t, err)
+	require.Equal(t, 8080, port)
+
+	host, err := cfg.Get("server.host", contract.String)
+	require.NoError(t, err)
+	require.Equal(t, "localhost", host)
+
+	enabled, err := cfg.Get("server.tls.enabled", contract.Bool)
+	require.NoError(t, err)
+	require.Equal(t, true, enabled)
+
+	require.True(t, cfg.Has("app.name"))
+}
+
+func TestNewForTest_DeepCopiesInput(t *testing.T) {
+	t.Parallel()
+	roles := []string{"admin", "user"}
+	input := map[string]any{"auth": map[string]any{"roles": roles}}
+
+	first := configtest.NewForTest(input)
+	second := configtest.NewForTest(input)
+
+	roles[0] = "mutated"
+	input["auth"].(map[string]any)["extra"] = "x"
+
+	for _, cfg := range []interface {
+		Get(key string, typ contract.KeyType) (any, error)
+		Has(key string) bool
+	}{first, second} {
+		got, err := cfg.Get("auth.roles", contract.StringSlice)
+		require.NoError(t, err)
+		require.Equal(t, []string{"admin", "user"}, got)
+		require.False(t, cfg.Has("auth.extra"))
+	}
+
+	// Changes to one Config do not leak into another built from the same map.
+	require.NoError(t, first.SetMap(map[string]any{"auth": map[string]any{"mode": "strict"}}))
+	require.True(t, first.Has("auth.mode"))
+	require.False(t, second.Has("auth.mode"))
+}
+
+func TestNewForTest_IgnoresEnvironment(t *testing.T) {
+	// Note: Cannot use t.Parallel() with t.Setenv()
+	t.Setenv("SERVER_HOST", "from-env")
+	t.Setenv("HOME", "/from-env")
+
+	cfg := configtest.NewForTest(map[string]any{"server.host": "fixture", "home": "/fixture"})
+	t.Cleanup(func() { _ = cfg.Close() })
+
+	host, err := cfg.Get("server.host", contract.String)
+	require.NoError(t, err)
+	require.Equal(t, "fixture", host)
+
+	home, err := cfg.Get("home", contract.String)
+	require.NoError(t, err)
+	require.Equal(t, "/fixture", home)
+
+	require.False(t, cfg.Has("path"))
+}
@@ -0,0 +1,3 @@
+// Package configtest builds ready-to-use Configs from literal maps for tests,
+// without fake providers or temporary files.
+package configtest
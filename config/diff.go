@@ -0,0 +1,44 @@
+package config
+
+import (
+	"reflect"
+	"sort"
+
+	"github.com/next-trace/scg-config/dotmap"
+)
+
+// Change describes a leaf key whose value differs between two configurations.
+// Old is nil for added keys and New is nil for removed keys.
+type Change struct {
+	Key string
+	Old any
+	New any
+}
+
+// Diff compares two nested configuration maps and returns the changed leaf
+// keys in dot notation, sorted by key.
+func Diff(oldSettings, newSettings map[string]any) []Change {
+	oldFlat := dotmap.Flatten(oldSettings)
+	newFlat := dotmap.Flatten(newSettings)
+
+	var changes []Change
+
+	for key, oldValue := range oldFlat {
+		newValue, ok := newFlat[key]
+		if !ok {
+			changes = append(changes, Change{Key: key, Old: oldValue, New: nil})
+		} else if !reflect.DeepEqual(oldValue, newValue) {
+			changes = append(changes, Change{Key: key, Old: oldValue, New: newValue})
+		}
+	}
+
+	for key, newValue := range newFlat {
+		if _, ok := oldFlat[key]; !ok {
+			changes = append(changes, Change{Key: key, Old: nil, New: newValue})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Key < changes[j].Key })
+
+	return changes
+}
@@ -0,0 +1,78 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/next-trace/scg-config/dotmap"
+	"github.com/next-trace/scg-config/utils"
+)
+
+// ToEnv renders the current configuration as sorted "KEY=value" lines, the
+// inverse of loading environment variables with the same prefix: the key
+// database.host with prefix "app" becomes APP_DATABASE_HOST. Slices are joined
+// with commas (a, b -> "a,b"), which Load and GetStringSliceCSV split again.
+// Keys that themselves contain underscores, and slices of maps, do not
+// round-trip.
+func (c *Config) ToEnv(prefix string) []string {
+	flat := dotmap.Flatten(c.snapshot().config)
+	prefix = utils.NormalizePrefix(prefix)
+
+	lines := make([]string, 0, len(flat))
+	for _, key := range dotmap.SortedKeys(flat) {
+		name := prefix + strings.ToUpper(strings.ReplaceAll(key, ".", "_"))
+		lines = append(lines, name+"="+envValue(flat[key]))
+	}
+
+	return lines
+}
+
+// AsFlatStringMap returns every leaf value as a string keyed by its dotted
+// path, ready for text/template or for documenting the effective config.
+// Leaves are rendered with fmt.Sprint, slices are joined with commas exactly
+// as in ToEnv (["a", "b"] becomes "a,b"). text/template ranges over the map in
+// sorted key order; use AsFlatStringPairs when iterating it directly.
+func (c *Config) AsFlatStringMap() map[string]string {
+	flat := dotmap.Flatten(c.snapshot().config)
+
+	out := make(map[string]string, len(flat))
+	for key, value := range flat {
+		out[key] = envValue(value)
+	}
+
+	return out
+}
+
+// AsFlatStringPairs returns the entries of AsFlatStringMap sorted by key, each
+// Value holding a string, for output that must not depend on map iteration
+// order.
+func (c *Config) AsFlatStringPairs() dotmap.OrderedMap {
+	flat := dotmap.Flatten(c.snapshot().config)
+
+	pairs := make(dotmap.OrderedMap, 0, len(flat))
+	for _, key := range dotmap.SortedKeys(flat) {
+		pairs = append(pairs, dotmap.Entry{Key: key, Value: envValue(flat[key])})
+	}
+
+	return pairs
+}
+
+// envValue formats a leaf value for ToEnv and AsFlatStringMap.
+func envValue(value any) string {
+	if value == nil {
+		return ""
+	}
+
+	rv := reflect.ValueOf(value)
+	if rv.Kind() == reflect.Slice && rv.Type().Elem().Kind() != reflect.Uint8 {
+		parts := make([]string, rv.Len())
+		for i := range parts {
+			parts[i] = fmt.Sprint(rv.Index(i).Interface())
+		}
+
+		return strings.Join(parts, utils.DefaultListDelimiter)
+	}
+
+	return fmt.Sprint(value)
+}
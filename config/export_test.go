@@ -0,0 +1,71 @@
+package config_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/next-trace/scg-config/config"
+	"github.com/next-trace/scg-config/contract"
+	"github.com/next-trace/scg-config/dotmap"
+	"github.com/next-trace/scg-config/loader/env"
+)
+
+func TestConfig_ToEnv_RoundTrips(t *testing.T) {
+	t.Parallel()
+	cfg := config.New(config.WithInitialValues(map[string]any{
+		"app":      map[string]any{"name": "demo", "debug": true},
+		"server":   map[string]any{"port": 8080},
+		"auth":     map[string]any{"roles": []any{"admin", "user"}},
+		"database": map[string]any{"host": "db.internal"},
+	}))
+
+	lines := cfg.ToEnv("app")
+	require.Equal(t, []string{
+		"APP_APP_DEBUG=true",
+		"APP_APP_NAME=demo",
+		"APP_AUTH_ROLES=admin,user",
+		"APP_DATABASE_HOST=db.internal",
+		"APP_SERVER_PORT=8080",
+	}, lines)
+
+	// Loading the lines back yields the same values.
+	restored := config.New()
+	require.NoError(t, env.NewEnvLoader(restored.Provider()).LoadFromEnvSlice("app", lines))
+	require.NoError(t, restored.Reload())
+
+	port, err := restored.Get("server.port", contract.Int)
+	require.NoError(t, err)
+	require.Equal(t, 8080, port)
+
+	// Lists come back as one comma-separated string, which Load splits.
+	var roles []string
+	require.NoError(t, restored.LoadKey("auth.roles", &roles))
+	require.Equal(t, []string{"admin", "user"}, roles)
+	require.Equal(t, cfg.ToEnv("app"), restored.ToEnv("app"))
+
+	require.Contains(t, cfg.ToEnv(""), "SERVER_PORT=8080")
+}
+
+func TestConfig_AsFlatStringMap(t *testing.T) {
+	t.Parallel()
+	cfg := config.New(config.WithInitialValues(map[string]any{
+		"app":    map[string]any{"name": "demo", "debug": true},
+		"server": map[string]any{"port": 8080},
+		"auth":   map[string]any{"roles": []any{"admin", "user"}},
+	}))
+
+	require.Equal(t, map[string]string{
+		"app.debug":   "true",
+		"app.name":    "demo",
+		"auth.roles":  "admin,user",
+		"server.port": "8080",
+	}, cfg.AsFlatStringMap())
+
+	require.Equal(t, dotmap.OrderedMap{
+		{Key: "app.debug", Value: "true"},
+		{Key: "app.name", Value: "demo"},
+		{Key: "auth.roles", Value: "admin,user"},
+		{Key: "server.port", Value: "8080"},
+	}, cfg.AsFlatStringPairs())
+}
@@ -0,0 +1,54 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/next-trace/scg-config/configerrors"
+)
+
+// Freeze makes the Config immutable, e.g. once the bootstrap phase is over.
+// Afterwards every method that would change the configuration (ReadInConfig,
+// Reload, ReloadValidated, ReloadSection, SetMap, ReplaceAll, Transaction,
+// MergeProvider, ApplyOverrides, UseProfile, StartWatching and StartStreaming)
+// returns ErrFrozen, watching is stopped and pending watcher reloads are
+// dropped. Reloads triggered by ReloadOnSignal or a stream push are ignored
+// without touching LastReloadError. Reads keep working on the frozen values.
+// Freeze is a one-way latch enforced by the Config regardless of the provider;
+// writes made directly through Provider() or the loaders bypass it. It returns
+// the error of closing the watcher, if any.
+func (c *Config) Freeze() error {
+	c.mu.Lock()
+	if c.frozen {
+		c.mu.Unlock()
+
+		return nil
+	}
+
+	c.frozen = true
+	c.mu.Unlock()
+
+	c.throttle.stop()
+
+	if err := c.watcher.Close(); err != nil {
+		return fmt.Errorf("error closing watcher: %w", err)
+	}
+
+	return nil
+}
+
+// IsFrozen reports whether Freeze has been called.
+func (c *Config) IsFrozen() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.frozen
+}
+
+// checkFrozen returns ErrFrozen once the Config has been frozen.
+func (c *Config) checkFrozen() error {
+	if c.IsFrozen() {
+		return configerrors.ErrFrozen
+	}
+
+	return nil
+}
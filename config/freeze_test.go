@@ -0,0 +1,61 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/next-trace/scg-config/config"
+	"github.com/next-trace/scg-config/configerrors"
+	"github.com/next-trace/scg-config/contract"
+)
+
+func TestConfig_Freeze(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("app:\n  name: svc\nprofiles:\n  prod:\n    app:\n      name: prod\n"), 0o600))
+
+	w := &fakeWatcher{}
+	cfg := config.New(config.WithWatcher(w))
+	require.NoError(t, cfg.FileLoader().LoadFromFile(path))
+	require.NoError(t, cfg.Reload())
+	require.False(t, cfg.IsFrozen())
+
+	require.NoError(t, cfg.Freeze())
+	require.NoError(t, cfg.Freeze())
+	assert.True(t, cfg.IsFrozen())
+	assert.True(t, w.closed)
+
+	var out struct {
+		App struct {
+			Name string `mapstructure:"name"`
+		} `mapstructure:"app"`
+	}
+
+	require.ErrorIs(t, cfg.Reload(), configerrors.ErrFrozen)
+	require.ErrorIs(t, cfg.ReadInConfig(), configerrors.ErrFrozen)
+	require.ErrorIs(t, cfg.ReloadValidated(&out), configerrors.ErrFrozen)
+	require.ErrorIs(t, cfg.SetMap(map[string]any{"app": map[string]any{"name": "x"}}), configerrors.ErrFrozen)
+	require.ErrorIs(t, cfg.ReplaceAll(map[string]any{"k": "v"}), configerrors.ErrFrozen)
+	require.ErrorIs(t, cfg.MergeProvider(&fakeProvider{all: map[string]any{"k": "v"}}), configerrors.ErrFrozen)
+	require.ErrorIs(t, cfg.UseProfile("prod"), configerrors.ErrFrozen)
+	require.ErrorIs(t, cfg.StartWatching(path), configerrors.ErrFrozen)
+	require.ErrorIs(t, cfg.ApplyOverrides([]string{"k=v"}), configerrors.ErrFrozen)
+	require.ErrorIs(t, cfg.ReloadSection("app", path, ""), configerrors.ErrFrozen)
+	require.ErrorIs(t, cfg.Transaction(func(tx *config.Tx) error {
+		tx.Set("k", "v")
+
+		return nil
+	}), configerrors.ErrFrozen)
+
+	name, err := cfg.Get("app.name", contract.String)
+	require.NoError(t, err)
+	assert.Equal(t, "svc", name)
+	assert.False(t, cfg.Has("k"))
+
+	require.NoError(t, cfg.Load(&out))
+	assert.Equal(t, "svc", out.App.Name)
+}
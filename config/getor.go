@@ -0,0 +1,129 @@
+package config
+
+import (
+	"net/url"
+	"reflect"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/next-trace/scg-config/contract"
+)
+
+// GetOr returns the value of key converted to T, or def when the key is
+// missing or cannot be converted. Types with a matching contract.KeyType (int,
+// string, bool, time.Duration, []string, ...), and slices or string-keyed maps
+// of them such as []int (see contract.SliceOf), use the same conversions as
+// Get; any other T (e.g. a struct) is decoded like LoadKey.
+//
+//	port := config.GetOr(cfg, "server.port", 8080)
+func GetOr[T any](c *Config, key string, def T) T {
+	if typ, ok := keyTypeOf(def); ok {
+		value, err := c.Get(key, typ)
+		if err != nil {
+			return def
+		}
+
+		typed, ok := value.(T)
+		if !ok {
+			return def
+		}
+
+		return typed
+	}
+
+	raw, ok := c.GetRawKey(key)
+	if !ok {
+		return def
+	}
+
+	var out T
+	if err := decodeAndValidate(raw, &out, key); err != nil {
+		return def
+	}
+
+	return out
+}
+
+// keyTypeOf returns the KeyType whose conversion yields the dynamic type of v.
+func keyTypeOf(v any) (contract.KeyType, bool) {
+	if v == nil {
+		return "", false
+	}
+
+	return keyTypeFor(reflect.TypeOf(v))
+}
+
+// keyTypeFor returns the KeyType whose conversion yields goType, composing
+// contract.SliceOf and contract.MapOf for other slices and string-keyed maps.
+func keyTypeFor(goType reflect.Type) (contract.KeyType, bool) {
+	for typ, candidate := range keyGoTypes {
+		if candidate == goType {
+			return typ, true
+		}
+	}
+
+	switch {
+	case goType.Kind() == reflect.Slice:
+		if elem, ok := keyTypeFor(goType.Elem()); ok {
+			return contract.SliceOf(elem), true
+		}
+	case goType.Kind() == reflect.Map && goType.Key().Kind() == reflect.String:
+		if elem, ok := keyTypeFor(goType.Elem()); ok {
+			return contract.MapOf(elem), true
+		}
+	}
+
+	return "", false
+}
+
+// goTypeOf returns the Go type that Get yields for typ, including composite
+// KeyTypes built by contract.SliceOf and contract.MapOf.
+func goTypeOf(typ contract.KeyType) (reflect.Type, bool) {
+	if goType, ok := keyGoTypes[typ]; ok {
+		return goType, true
+	}
+
+	elem, isSlice, ok := typ.Elem()
+	if !ok {
+		return nil, false
+	}
+
+	elemType, ok := goTypeOf(elem)
+	if !ok {
+		return nil, false
+	}
+
+	if isSlice {
+		return reflect.SliceOf(elemType), true
+	}
+
+	return reflect.MapOf(reflect.TypeFor[string](), elemType), true
+}
+
+// keyGoTypes maps every KeyType with a dedicated converter to the Go type the
+// conversion yields.
+//
+//nolint:gochecknoglobals // a lookup table shared by keyTypeFor and goTypeOf
+var keyGoTypes = map[contract.KeyType]reflect.Type{
+	contract.String:               reflect.TypeFor[string](),
+	contract.Int:                  reflect.TypeFor[int](),
+	contract.Int32:                reflect.TypeFor[int32](),
+	contract.Int64:                reflect.TypeFor[int64](),
+	contract.Uint:                 reflect.TypeFor[uint](),
+	contract.Uint32:               reflect.TypeFor[uint32](),
+	contract.Uint64:               reflect.TypeFor[uint64](),
+	contract.Float32:              reflect.TypeFor[float32](),
+	contract.Float64:              reflect.TypeFor[float64](),
+	contract.Bool:                 reflect.TypeFor[bool](),
+	contract.Duration:             reflect.TypeFor[time.Duration](),
+	contract.Time:                 reflect.TypeFor[time.Time](),
+	contract.StringSlice:          reflect.TypeFor[[]string](),
+	contract.DurationSlice:        reflect.TypeFor[[]time.Duration](),
+	contract.Map:                  reflect.TypeFor[map[string]any](),
+	contract.StringMapInt:         reflect.TypeFor[map[string]int](),
+	contract.StringMapStringSlice: reflect.TypeFor[map[string][]string](),
+	contract.Bytes:                reflect.TypeFor[[]byte](),
+	contract.UUID:                 reflect.TypeFor[uuid.UUID](),
+	contract.URL:                  reflect.TypeFor[*url.URL](),
+}
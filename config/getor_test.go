@@ -0,0 +1,64 @@
+package config_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/next-trace/scg-config/config"
+)
+
+func TestGetOr(t *testing.T) {
+	t.Parallel()
+	prov := &fakeProvider{all: map[string]any{
+		"server": map[string]any{
+			"port":    "9090",
+			"host":    "example.com",
+			"debug":   "true",
+			"ratio":   0.5,
+			"timeout": "3s",
+			"tags":    []any{"a", "b"},
+			"workers": 4,
+		},
+		"limits": map[string]any{"burst": 10, "rate": "fast"},
+	}}
+	cfg := config.New(config.WithProvider(prov))
+
+	assert.Equal(t, 9090, config.GetOr(cfg, "server.port", 8080))
+	assert.Equal(t, "example.com", config.GetOr(cfg, "server.host", "localhost"))
+	assert.True(t, config.GetOr(cfg, "server.debug", false))
+	assert.InEpsilon(t, 0.5, config.GetOr(cfg, "server.ratio", 1.0), 1e-9)
+	assert.Equal(t, 3*time.Second, config.GetOr(cfg, "server.timeout", time.Second))
+	assert.Equal(t, []string{"a", "b"}, config.GetOr(cfg, "server.tags", []string{"x"}))
+	assert.Equal(t, uint(4), config.GetOr(cfg, "server.workers", uint(1)))
+
+	// Missing keys return the default.
+	assert.Equal(t, 8080, config.GetOr(cfg, "server.missing", 8080))
+	assert.Equal(t, "fallback", config.GetOr(cfg, "missing", "fallback"))
+
+	// Mistyped values return the default.
+	assert.Equal(t, 8080, config.GetOr(cfg, "server.host", 8080))
+	assert.False(t, config.GetOr(cfg, "server.host", false))
+	assert.Equal(t, time.Minute, config.GetOr(cfg, "server.host", time.Minute))
+
+	// Other types are decoded like LoadKey.
+	type limits struct {
+		Burst int `mapstructure:"burst"`
+	}
+
+	assert.Equal(t, limits{Burst: 10}, config.GetOr(cfg, "limits", limits{Burst: 1}))
+	assert.Equal(t, int8(7), config.GetOr(cfg, "limits.rate", int8(7)))
+}
+
+func TestGetOr_CompositeTypes(t *testing.T) {
+	t.Parallel()
+	cfg := config.New(config.WithProvider(&fakeProvider{all: map[string]any{
+		"ports":  []any{"80", 443},
+		"limits": map[string]any{"read": 1.5},
+	}}))
+
+	assert.Equal(t, []int{80, 443}, config.GetOr(cfg, "ports", []int{8080}))
+	assert.Equal(t, map[string]float64{"read": 1.5}, config.GetOr(cfg, "limits", map[string]float64{}))
+	assert.Equal(t, []bool{true}, config.GetOr(cfg, "missing", []bool{true}))
+}
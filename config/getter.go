@@ -1,8 +1,19 @@
 package config
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/url"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/google/uuid"
+
 	"github.com/next-trace/scg-config/configerrors"
 	"github.com/next-trace/scg-config/contract"
 	"github.com/next-trace/scg-config/dotmap"
@@ -13,11 +24,116 @@ import (
 // snapshot map captured from the Provider.
 type Getter struct {
 	config map[string]any
+	// leaves indexes every non-nil leaf of config by its dotted path, so leaf
+	// lookups are a single map access instead of a dotmap traversal.
+	leaves       map[string]any
+	transformers []contract.ValueTransformer
 }
 
-// NewGetter creates a Getter over the provided configuration map.
+// NewGetter creates a Getter over a deep copy of the provided configuration
+// map, so later changes to config do not affect the Getter.
 func NewGetter(config map[string]any) *Getter {
-	return &Getter{config: config}
+	copied, _ := deepCopy(config).(map[string]any)
+
+	return newOwnedGetter(copied)
+}
+
+// newOwnedGetter creates a Getter that takes ownership of config, which no one
+// else may modify, and builds its leaf index once.
+func newOwnedGetter(config map[string]any) *Getter {
+	leaves := make(map[string]any)
+	indexLeaves(leaves, "", config)
+
+	return &Getter{config: config, leaves: leaves}
+}
+
+// deepCopy returns a copy of v in which every map and slice, at any depth, is
+// newly allocated. Other values are returned as is.
+func deepCopy(v any) any {
+	value := reflect.ValueOf(v)
+
+	switch value.Kind() {
+	case reflect.Map:
+		if value.IsNil() {
+			return v
+		}
+
+		result := reflect.MakeMapWithSize(value.Type(), value.Len())
+		for iter := value.MapRange(); iter.Next(); {
+			result.SetMapIndex(iter.Key(), copyElem(iter.Value(), value.Type().Elem()))
+		}
+
+		return result.Interface()
+	case reflect.Slice:
+		if value.IsNil() {
+			return v
+		}
+
+		result := reflect.MakeSlice(value.Type(), value.Len(), value.Len())
+		for i := range value.Len() {
+			result.Index(i).Set(copyElem(value.Index(i), value.Type().Elem()))
+		}
+
+		return result.Interface()
+	default:
+		return v
+	}
+}
+
+// copyElem deep-copies a map or slice element of type typ.
+func copyElem(elem reflect.Value, typ reflect.Type) reflect.Value {
+	copied := deepCopy(elem.Interface())
+	if copied == nil {
+		return reflect.Zero(typ)
+	}
+
+	return reflect.ValueOf(copied).Convert(typ)
+}
+
+// indexLeaves records the non-nil leaves of value under their dotted paths.
+// Segments that dotmap.Resolve would read differently (containing a dot or
+// parsing as a slice index) are skipped, so an index hit always agrees with
+// the traversal. Nested maps and paths into slices are left to dotmap.
+func indexLeaves(leaves map[string]any, prefix string, value any) {
+	switch typed := value.(type) {
+	case nil:
+	case map[string]any:
+		for key, child := range typed {
+			if indexableSegment(key) {
+				indexLeaves(leaves, joinKey(prefix, key), child)
+			}
+		}
+	case map[any]any:
+		for key, child := range typed {
+			if name, ok := key.(string); ok && indexableSegment(name) {
+				indexLeaves(leaves, joinKey(prefix, name), child)
+			}
+		}
+	default:
+		if prefix != "" {
+			leaves[prefix] = typed
+		}
+	}
+}
+
+// indexableSegment reports whether key resolves as a plain map key.
+func indexableSegment(key string) bool {
+	if key == "" || strings.Contains(key, ".") {
+		return false
+	}
+
+	_, err := strconv.Atoi(key)
+
+	return err != nil
+}
+
+// joinKey appends key to the dotted prefix.
+func joinKey(prefix, key string) string {
+	if prefix == "" {
+		return key
+	}
+
+	return prefix + "." + key
 }
 
 // Get returns the value associated with key, converted to the provided KeyType.
@@ -28,30 +144,69 @@ func (gt *Getter) Get(key string, typ contract.KeyType) (any, error) {
 	}
 
 	if value, ok := gt.config[key]; ok {
-		result, err := tryTypeCast(value, typ)
+		value, err := gt.transform(key, value)
 		if err != nil {
 			return nil, err
 		}
 
+		result, err := tryTypeCast(value, typ)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", configerrors.ErrWrongType, err)
+		}
+
 		return result, nil
 	}
 
-	value := dotmap.Resolve(gt.config, key)
-	if value == nil {
+	value, ok := gt.resolve(key)
+	if !ok {
 		return nil, configerrors.ErrKeyNotFound
 	}
 
-	value, err := tryTypeCast(value, typ)
+	value, err := gt.transform(key, value)
 	if err != nil {
-		return nil, configerrors.ErrWrongType
+		return nil, err
+	}
+
+	value, err = tryTypeCast(value, typ)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", configerrors.ErrWrongType, err)
+	}
+
+	return value, nil
+}
+
+// Lookup returns the value of key converted to typ and whether the key exists,
+// separating "absent" from "present but wrong": a missing key yields found ==
+// false and a nil error, while an existing key that cannot be converted yields
+// found == true and the conversion error.
+func (gt *Getter) Lookup(key string, typ contract.KeyType) (value any, found bool, err error) {
+	if !gt.HasKey(key) {
+		return nil, false, nil
+	}
+
+	value, err = gt.Get(key, typ)
+
+	return value, true, err
+}
+
+// transform applies the registered value transformers to value in order.
+func (gt *Getter) transform(key string, value any) (any, error) {
+	for _, transformer := range gt.transformers {
+		transformed, err := transformer.Transform(key, value)
+		if err != nil {
+			return nil, fmt.Errorf("config: transforming %q: %w", key, err)
+		}
+
+		value = transformed
 	}
 
 	return value, nil
 }
 
 // GetKey returns the raw value for key as any, or nil when not found.
+// No type conversion is applied to the resolved value.
 func (gt *Getter) GetKey(key string) any {
-	value, _ := gt.Get(key, contract.String)
+	value, _ := gt.lookup(key)
 
 	return value
 }
@@ -106,6 +261,16 @@ func (gt *Getter) GetDuration(key string) time.Duration {
 	return 0
 }
 
+// GetDurationSlice returns a []time.Duration for key, or nil if not found/convertible.
+func (gt *Getter) GetDurationSlice(key string) []time.Duration {
+	value, _ := gt.Get(key, contract.DurationSlice)
+	if slice, ok := value.([]time.Duration); ok {
+		return slice
+	}
+
+	return nil
+}
+
 // GetInt64 returns the int64 value for key, or 0 if not found/convertible.
 func (gt *Getter) GetInt64(key string) int64 {
 	value, _ := gt.Get(key, contract.Int64)
@@ -126,6 +291,46 @@ func (gt *Getter) GetStringSlice(key string) []string {
 	return nil
 }
 
+// GetStringSliceOr returns the []string for key, or a copy of def when the key
+// is missing or not convertible. An explicitly empty list yields []string{},
+// not def.
+func (gt *Getter) GetStringSliceOr(key string, def []string) []string {
+	return sliceOr(gt, key, contract.StringSlice, def)
+}
+
+// GetIntSliceOr returns the []int for key, or a copy of def when the key is
+// missing or not convertible. An explicitly empty list yields []int{}.
+func (gt *Getter) GetIntSliceOr(key string, def []int) []int {
+	return sliceOr(gt, key, contract.SliceOf(contract.Int), def)
+}
+
+// GetFloat64SliceOr returns the []float64 for key, or a copy of def when the
+// key is missing or not convertible. An explicitly empty list yields
+// []float64{}.
+func (gt *Getter) GetFloat64SliceOr(key string, def []float64) []float64 {
+	return sliceOr(gt, key, contract.SliceOf(contract.Float64), def)
+}
+
+// sliceOr implements the slice *Or getters. def is cloned so callers never
+// share its backing array with the result.
+func sliceOr[T any](gt *Getter, key string, typ contract.KeyType, def []T) []T {
+	value, err := gt.Get(key, typ)
+	if err != nil {
+		return slices.Clone(def)
+	}
+
+	slice, ok := value.([]T)
+	if !ok {
+		return slices.Clone(def)
+	}
+
+	if slice == nil {
+		return []T{}
+	}
+
+	return slice
+}
+
 // GetStringMap returns a map[string]interface{} for key, or nil if not found/convertible.
 func (gt *Getter) GetStringMap(key string) map[string]interface{} {
 	value, _ := gt.Get(key, contract.Map)
@@ -146,6 +351,43 @@ func (gt *Getter) GetStringMapString(key string) map[string]string {
 	return nil
 }
 
+// GetStringSliceCSV returns a []string for key, splitting a single string value
+// on delim (comma when empty) with surrounding whitespace trimmed. It returns
+// nil if the key is not found, fails to transform or is not convertible.
+func (gt *Getter) GetStringSliceCSV(key, delim string) []string {
+	value, err := gt.lookupTransformed(key)
+	if err != nil {
+		return nil
+	}
+
+	slice, err := utils.ToStringSliceDelim(value, delim)
+	if err != nil {
+		return nil
+	}
+
+	return slice
+}
+
+// GetStringMapStringSlice returns a map[string][]string for key, or nil if not found/convertible.
+func (gt *Getter) GetStringMapStringSlice(key string) map[string][]string {
+	value, _ := gt.Get(key, contract.StringMapStringSlice)
+	if mapValue, ok := value.(map[string][]string); ok {
+		return mapValue
+	}
+
+	return nil
+}
+
+// GetStringMapInt returns a map[string]int for key, or nil if not found/convertible.
+func (gt *Getter) GetStringMapInt(key string) map[string]int {
+	value, _ := gt.Get(key, contract.StringMapInt)
+	if mapValue, ok := value.(map[string]int); ok {
+		return mapValue
+	}
+
+	return nil
+}
+
 // GetTime returns the time.Time value for key, or the zero time if not found/convertible.
 func (gt *Getter) GetTime(key string) time.Time {
 	value, _ := gt.Get(key, contract.Time)
@@ -156,6 +398,319 @@ func (gt *Getter) GetTime(key string) time.Time {
 	return time.Time{}
 }
 
+// GetUUID returns the uuid.UUID value for key, parsing string values, or
+// uuid.Nil if not found/convertible.
+func (gt *Getter) GetUUID(key string) uuid.UUID {
+	value, _ := gt.Get(key, contract.UUID)
+	if uuidValue, ok := value.(uuid.UUID); ok {
+		return uuidValue
+	}
+
+	return uuid.Nil
+}
+
+// GetURL returns the *url.URL value for key, parsing string values, or nil if
+// not found/convertible.
+func (gt *Getter) GetURL(key string) *url.URL {
+	value, _ := gt.Get(key, contract.URL)
+	if urlValue, ok := value.(*url.URL); ok {
+		return urlValue
+	}
+
+	return nil
+}
+
+// GetTimeLayout returns the time.Time value for key, parsing string values with
+// layout (see time.Parse), e.g. "2006/01/02 15:04" for legacy timestamps.
+// time.Time values are returned as is. Parse failures return ErrNotTime
+// wrapping the parse error.
+func (gt *Getter) GetTimeLayout(key, layout string) (time.Time, error) {
+	value, err := gt.lookupTransformed(key)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	switch typed := value.(type) {
+	case time.Time:
+		return typed, nil
+	case string:
+		parsed, err := time.Parse(layout, typed)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("%w: %w", configerrors.ErrNotTime, err)
+		}
+
+		return parsed, nil
+	default:
+		return time.Time{}, configerrors.ErrNotTime
+	}
+}
+
+// GetDurationUnit returns the duration for key, reading bare numbers (and
+// numeric strings) as a count of unit, so GetDurationUnit("timeout",
+// time.Second) turns 30 into 30s. Duration strings such as "1m30s" and
+// time.Duration values are returned as is. Other values, and counts whose
+// duration does not fit in a time.Duration, return ErrNotDuration.
+func (gt *Getter) GetDurationUnit(key string, unit time.Duration) (time.Duration, error) {
+	value, err := gt.lookupTransformed(key)
+	if err != nil {
+		return 0, err
+	}
+
+	return durationUnit(value, unit)
+}
+
+// durationUnit implements GetDurationUnit for a resolved value.
+func durationUnit(value any, unit time.Duration) (time.Duration, error) {
+	switch typed := value.(type) {
+	case time.Duration:
+		return typed, nil
+	case json.Number:
+		// Parse the literal as a string, like the utils conversions.
+		return durationUnit(typed.String(), unit)
+	case string:
+		if d, err := time.ParseDuration(typed); err == nil {
+			return d, nil
+		}
+
+		if count, err := strconv.ParseInt(typed, 10, 64); err == nil {
+			return scaleDuration(count, unit)
+		}
+
+		count, err := strconv.ParseFloat(typed, 64)
+		if err != nil {
+			return 0, fmt.Errorf("%w: %q is neither a duration nor a number", configerrors.ErrNotDuration, typed)
+		}
+
+		return scaleDurationFloat(count, unit)
+	}
+
+	number := reflect.ValueOf(value)
+
+	switch {
+	case number.CanInt():
+		return scaleDuration(number.Int(), unit)
+	case number.CanUint():
+		if number.Uint() > math.MaxInt64 {
+			return 0, fmt.Errorf("%w: %d %s overflows time.Duration", configerrors.ErrNotDuration, number.Uint(), unit)
+		}
+
+		return scaleDuration(int64(number.Uint()), unit)
+	case number.CanFloat():
+		return scaleDurationFloat(number.Float(), unit)
+	default:
+		return 0, configerrors.ErrNotDuration
+	}
+}
+
+// scaleDuration returns count units, failing with ErrNotDuration instead of
+// wrapping around when the product does not fit in a time.Duration.
+func scaleDuration(count int64, unit time.Duration) (time.Duration, error) {
+	d := time.Duration(count) * unit
+	if count != 0 && (d/time.Duration(count) != unit || (count == -1 && unit == math.MinInt64)) {
+		return 0, fmt.Errorf("%w: %d %s overflows time.Duration", configerrors.ErrNotDuration, count, unit)
+	}
+
+	return d, nil
+}
+
+// scaleDurationFloat is scaleDuration for fractional counts.
+func scaleDurationFloat(count float64, unit time.Duration) (time.Duration, error) {
+	d := count * float64(unit)
+	if math.IsNaN(d) || d >= math.MaxInt64 || d < math.MinInt64 {
+		return 0, fmt.Errorf("%w: %v %s overflows time.Duration", configerrors.ErrNotDuration, count, unit)
+	}
+
+	return time.Duration(d), nil
+}
+
+// getAs returns the value for key converted via typ and asserted to T,
+// surfacing ErrKeyNotFound and conversion errors.
+func getAs[T any](gt *Getter, key string, typ contract.KeyType) (T, error) {
+	var zero T
+
+	value, err := gt.Get(key, typ)
+	if err != nil {
+		return zero, err
+	}
+
+	typed, ok := value.(T)
+	if !ok {
+		return zero, configerrors.ErrWrongType
+	}
+
+	return typed, nil
+}
+
+// GetStringE returns the string value for key or an error if not found/convertible.
+func (gt *Getter) GetStringE(key string) (string, error) {
+	return getAs[string](gt, key, contract.String)
+}
+
+// GetIntE returns the int value for key or an error if not found/convertible.
+func (gt *Getter) GetIntE(key string) (int, error) {
+	return getAs[int](gt, key, contract.Int)
+}
+
+// GetInt64E returns the int64 value for key or an error if not found/convertible.
+func (gt *Getter) GetInt64E(key string) (int64, error) {
+	return getAs[int64](gt, key, contract.Int64)
+}
+
+// GetBoolE returns the bool value for key or an error if not found/convertible.
+func (gt *Getter) GetBoolE(key string) (bool, error) {
+	return getAs[bool](gt, key, contract.Bool)
+}
+
+// GetFloat64E returns the float64 value for key or an error if not found/convertible.
+func (gt *Getter) GetFloat64E(key string) (float64, error) {
+	return getAs[float64](gt, key, contract.Float64)
+}
+
+// GetDurationE returns the time.Duration value for key or an error if not found/convertible.
+func (gt *Getter) GetDurationE(key string) (time.Duration, error) {
+	return getAs[time.Duration](gt, key, contract.Duration)
+}
+
+// GetTimeE returns the time.Time value for key or an error if not found/convertible.
+func (gt *Getter) GetTimeE(key string) (time.Time, error) {
+	return getAs[time.Time](gt, key, contract.Time)
+}
+
+// GetStringSliceE returns the []string value for key or an error if not found/convertible.
+func (gt *Getter) GetStringSliceE(key string) ([]string, error) {
+	return getAs[[]string](gt, key, contract.StringSlice)
+}
+
+// GetStringMapE returns the map[string]interface{} value for key or an error if not found/convertible.
+func (gt *Getter) GetStringMapE(key string) (map[string]interface{}, error) {
+	return getAs[map[string]interface{}](gt, key, contract.Map)
+}
+
+// GetStringMapStringSliceE returns the map[string][]string value for key or an error if not found/convertible.
+func (gt *Getter) GetStringMapStringSliceE(key string) (map[string][]string, error) {
+	return getAs[map[string][]string](gt, key, contract.StringMapStringSlice)
+}
+
+// GetStringMapIntE returns the map[string]int value for key or an error if not found/convertible.
+func (gt *Getter) GetStringMapIntE(key string) (map[string]int, error) {
+	return getAs[map[string]int](gt, key, contract.StringMapInt)
+}
+
+// GetDurationSliceE returns the []time.Duration value for key or an error if not found/convertible.
+func (gt *Getter) GetDurationSliceE(key string) ([]time.Duration, error) {
+	return getAs[[]time.Duration](gt, key, contract.DurationSlice)
+}
+
+// GetIntInRange returns the int value for key, checking that it lies within the
+// inclusive range [minValue, maxValue]. It returns ErrOutOfRange naming the key,
+// the bounds and the actual value when the check fails.
+func (gt *Getter) GetIntInRange(key string, minValue, maxValue int) (int, error) {
+	value, err := gt.Get(key, contract.Int)
+	if err != nil {
+		return 0, err
+	}
+
+	intValue, _ := value.(int)
+	if intValue < minValue || intValue > maxValue {
+		return 0, fmt.Errorf("%w: key %q value %d not in [%d, %d]",
+			configerrors.ErrOutOfRange, key, intValue, minValue, maxValue)
+	}
+
+	return intValue, nil
+}
+
+// GetFloat64InRange returns the float64 value for key, checking that it lies
+// within the inclusive range [minValue, maxValue]. It returns ErrOutOfRange
+// naming the key, the bounds and the actual value when the check fails.
+func (gt *Getter) GetFloat64InRange(key string, minValue, maxValue float64) (float64, error) {
+	value, err := gt.Get(key, contract.Float64)
+	if err != nil {
+		return 0, err
+	}
+
+	floatValue, _ := value.(float64)
+	if floatValue < minValue || floatValue > maxValue {
+		return 0, fmt.Errorf("%w: key %q value %g not in [%g, %g]",
+			configerrors.ErrOutOfRange, key, floatValue, minValue, maxValue)
+	}
+
+	return floatValue, nil
+}
+
+// Len returns the number of elements of the slice or map stored at key.
+// It returns 0 for scalar values and missing keys.
+func (gt *Getter) Len(key string) int {
+	value, ok := gt.lookup(key)
+	if !ok || value == nil {
+		return 0
+	}
+
+	switch reflect.ValueOf(value).Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return reflect.ValueOf(value).Len()
+	default:
+		return 0
+	}
+}
+
+// GetSliceIndex returns the raw element at index i of the slice stored at key.
+// It returns ErrKeyNotFound when the key is missing, ErrNotSlice when the value
+// is not a slice, and ErrIndexOutOfRange for negative or out-of-range indices.
+func (gt *Getter) GetSliceIndex(key string, i int) (any, error) {
+	value, ok := gt.lookup(key)
+	if !ok {
+		return nil, configerrors.ErrKeyNotFound
+	}
+
+	slice := reflect.ValueOf(value)
+	if slice.Kind() != reflect.Slice && slice.Kind() != reflect.Array {
+		return nil, configerrors.ErrNotSlice
+	}
+
+	if i < 0 || i >= slice.Len() {
+		return nil, fmt.Errorf("%w: index %d, length %d", configerrors.ErrIndexOutOfRange, i, slice.Len())
+	}
+
+	return slice.Index(i).Interface(), nil
+}
+
+// lookup returns the raw value for key using a flat lookup followed by
+// dot-notation path resolution.
+func (gt *Getter) lookup(key string) (any, bool) {
+	if key == "" || gt.config == nil {
+		return nil, false
+	}
+
+	if value, ok := gt.config[key]; ok {
+		return value, true
+	}
+
+	return gt.resolve(key)
+}
+
+// lookupTransformed returns the value for key with the value transformers
+// applied, as Get does before converting, or ErrKeyNotFound.
+func (gt *Getter) lookupTransformed(key string) (any, error) {
+	value, ok := gt.lookup(key)
+	if !ok {
+		return nil, configerrors.ErrKeyNotFound
+	}
+
+	return gt.transform(key, value)
+}
+
+// resolve returns the value at the dotted path key, answering leaf keys from
+// the index and falling back to dotmap for structural and case-folded paths.
+func (gt *Getter) resolve(key string) (any, bool) {
+	if value, ok := gt.leaves[key]; ok {
+		return value, true
+	}
+
+	value := dotmap.Resolve(gt.config, key)
+
+	return value, value != nil
+}
+
 // HasKey checks if a key exists in the configuration.
 // It first attempts a flat key lookup, then falls back to dot-notation path resolution.
 func (gt *Getter) HasKey(key string) bool {
@@ -167,7 +722,9 @@ func (gt *Getter) HasKey(key string) bool {
 		return true
 	}
 
-	return dotmap.Resolve(gt.config, key) != nil
+	_, ok := gt.resolve(key)
+
+	return ok
 }
 
 // TypeConverter defines a function that converts a value to a specific type.
@@ -252,6 +809,18 @@ var typeConverters = map[contract.KeyType]struct {
 		},
 		errorType: configerrors.ErrNotMap,
 	},
+	contract.StringMapStringSlice: {
+		converter: func(val any) (any, error) {
+			return utils.ToStringMapStringSlice(val)
+		},
+		errorType: configerrors.ErrNotStringMapStringSlice,
+	},
+	contract.StringMapInt: {
+		converter: func(val any) (any, error) {
+			return utils.ToStringMapInt(val)
+		},
+		errorType: configerrors.ErrNotMap,
+	},
 	contract.Time: {
 		converter: func(val any) (any, error) {
 			return utils.ToTime(val)
@@ -264,6 +833,12 @@ var typeConverters = map[contract.KeyType]struct {
 		},
 		errorType: configerrors.ErrNotDuration,
 	},
+	contract.DurationSlice: {
+		converter: func(val any) (any, error) {
+			return utils.ToDurationSlice(val)
+		},
+		errorType: configerrors.ErrNotDurationSlice,
+	},
 	contract.Bytes: {
 		converter: func(val any) (any, error) {
 			return utils.ToBytes(val)
@@ -289,13 +864,85 @@ var typeConverters = map[contract.KeyType]struct {
 func tryTypeCast(val any, typ contract.KeyType) (any, error) {
 	converterInfo, exists := typeConverters[typ]
 	if !exists {
-		return nil, configerrors.ErrUnknownType
+		return castComposite(val, typ)
 	}
 
 	value, err := converterInfo.converter(val)
 	if err != nil {
-		return nil, converterInfo.errorType
+		// Keep the converter's detail (e.g. the strconv failure) reachable while
+		// guaranteeing the category error is in the chain.
+		if errors.Is(err, converterInfo.errorType) {
+			return nil, err
+		}
+
+		return nil, fmt.Errorf("%w: %w", converterInfo.errorType, err)
 	}
 
 	return value, nil
 }
+
+// castComposite converts val for a KeyType built by contract.SliceOf or
+// contract.MapOf, running every element through the element KeyType. Errors
+// name the failing index or key.
+func castComposite(val any, typ contract.KeyType) (any, error) {
+	elem, isSlice, ok := typ.Elem()
+	if !ok {
+		return nil, configerrors.ErrUnknownType
+	}
+
+	goType, ok := goTypeOf(elem)
+	if !ok {
+		return nil, configerrors.ErrUnknownType
+	}
+
+	if isSlice {
+		return castSlice(val, elem, goType)
+	}
+
+	return castMap(val, elem, goType)
+}
+
+// castSlice converts every element of the list val to elem, returning a slice
+// of goType. Strings are not split; GetStringSliceCSV reads delimited values.
+func castSlice(val any, elem contract.KeyType, goType reflect.Type) (any, error) {
+	list := reflect.ValueOf(val)
+	if list.Kind() != reflect.Slice && list.Kind() != reflect.Array {
+		return nil, configerrors.ErrNotSlice
+	}
+
+	result := reflect.MakeSlice(reflect.SliceOf(goType), list.Len(), list.Len())
+
+	for i := range list.Len() {
+		converted, err := tryTypeCast(list.Index(i).Interface(), elem)
+		if err != nil {
+			return nil, fmt.Errorf("%w: element %d: %w", configerrors.ErrNotSlice, i, err)
+		}
+
+		result.Index(i).Set(reflect.ValueOf(converted))
+	}
+
+	return result.Interface(), nil
+}
+
+// castMap converts every value of the map val to elem, returning a
+// map[string] of goType. Keys are visited in sorted order so the reported
+// failing key is deterministic.
+func castMap(val any, elem contract.KeyType, goType reflect.Type) (any, error) {
+	entries, err := utils.ToMap(val)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", configerrors.ErrNotMap, err)
+	}
+
+	result := reflect.MakeMapWithSize(reflect.MapOf(reflect.TypeFor[string](), goType), len(entries))
+
+	for _, key := range dotmap.SortedKeys(entries) {
+		converted, err := tryTypeCast(entries[key], elem)
+		if err != nil {
+			return nil, fmt.Errorf("%w: key %q: %w", configerrors.ErrNotMap, key, err)
+		}
+
+		result.SetMapIndex(reflect.ValueOf(key), reflect.ValueOf(converted))
+	}
+
+	return result.Interface(), nil
+}
@@ -1,7 +1,14 @@
 package config_test
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
 	"net/url"
+	"slices"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
 
@@ -10,7 +17,9 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/next-trace/scg-config/config"
+	"github.com/next-trace/scg-config/configerrors"
 	"github.com/next-trace/scg-config/contract"
+	"github.com/next-trace/scg-config/dotmap"
 )
 
 func baseConfigMap() map[string]any {
@@ -182,3 +191,647 @@ func TestGetter_AdditionalNumericTypes(t *testing.T) {
 	require.NoError(t, err)
 	require.InDelta(t, float32(1.25), f, 0.0001)
 }
+
+func TestGetter_GetStringMapStringSlice(t *testing.T) {
+	t.Parallel()
+	conf := config.NewGetter(map[string]any{
+		"routes": map[string]any{"api": []any{"/v1", "/v2"}},
+		"bad":    map[string]any{"api": 1},
+	})
+
+	require.Equal(t, map[string][]string{"api": {"/v1", "/v2"}}, conf.GetStringMapStringSlice("routes"))
+	require.Nil(t, conf.GetStringMapStringSlice("bad"))
+	require.Nil(t, conf.GetStringMapStringSlice("missing"))
+
+	_, err := conf.Get("bad", contract.StringMapStringSlice)
+	require.ErrorIs(t, err, configerrors.ErrNotStringMapStringSlice)
+}
+
+func TestGetter_GetStringMapInt(t *testing.T) {
+	t.Parallel()
+	conf := config.NewGetter(map[string]any{
+		"limits": map[string]any{"read": 100, "write": "50"},
+		"bad":    map[string]any{"read": "lots"},
+	})
+
+	require.Equal(t, map[string]int{"read": 100, "write": 50}, conf.GetStringMapInt("limits"))
+	require.Nil(t, conf.GetStringMapInt("bad"))
+	require.Nil(t, conf.GetStringMapInt("missing"))
+}
+
+func TestGetter_Len(t *testing.T) {
+	t.Parallel()
+	conf := config.NewGetter(baseConfigMap())
+
+	assert.Equal(t, 2, conf.Len("strslice"))
+	assert.Equal(t, 2, conf.Len("anyslice"))
+	assert.Equal(t, 2, conf.Len("nestedslice.arr"))
+	assert.Equal(t, 1, conf.Len("smap"))
+	assert.Equal(t, 0, conf.Len("foo"))
+	assert.Equal(t, 0, conf.Len("missing"))
+}
+
+func TestGetter_GetSliceIndex(t *testing.T) {
+	t.Parallel()
+	conf := config.NewGetter(baseConfigMap())
+
+	v, err := conf.GetSliceIndex("nestedslice.arr", 1)
+	require.NoError(t, err)
+	assert.Equal(t, "y", v)
+
+	v, err = conf.GetSliceIndex("strslice", 0)
+	require.NoError(t, err)
+	assert.Equal(t, "a", v)
+
+	_, err = conf.GetSliceIndex("strslice", 2)
+	require.ErrorIs(t, err, configerrors.ErrIndexOutOfRange)
+	_, err = conf.GetSliceIndex("strslice", -1)
+	require.ErrorIs(t, err, configerrors.ErrIndexOutOfRange)
+	_, err = conf.GetSliceIndex("foo", 0)
+	require.ErrorIs(t, err, configerrors.ErrNotSlice)
+	_, err = conf.GetSliceIndex("missing", 0)
+	require.ErrorIs(t, err, configerrors.ErrKeyNotFound)
+}
+
+func TestGetter_GetKey_ReturnsRawValue(t *testing.T) {
+	t.Parallel()
+	conf := config.NewGetter(baseConfigMap())
+
+	assert.Equal(t, 123, conf.GetKey("foo"))
+	assert.Equal(t, 42, conf.GetKey("nested.deep.val"))
+	assert.Nil(t, conf.GetKey("missing"))
+}
+
+func TestGetter_GetKey_NoTypeCoercion(t *testing.T) {
+	t.Parallel()
+	conf := config.NewGetter(baseConfigMap())
+
+	tests := []struct {
+		name string
+		key  string
+		want any
+	}{
+		{"int", "foo", 123},
+		{"bool", "baz", true},
+		{"map", "smap", map[string]any{"k": "v"}},
+		{"slice", "anyslice", []any{"c", "d"}},
+		{"string", "bar", "abc"},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, testCase.want, conf.GetKey(testCase.key))
+		})
+	}
+}
+
+func TestGetter_GetDurationSlice(t *testing.T) {
+	t.Parallel()
+	conf := config.NewGetter(map[string]any{
+		"retry": map[string]any{"backoff": []any{"1s", "5s", "30s"}},
+		"bad":   []any{"1s", 5},
+	})
+
+	require.Equal(t, []time.Duration{time.Second, 5 * time.Second, 30 * time.Second}, conf.GetDurationSlice("retry.backoff"))
+	require.Nil(t, conf.GetDurationSlice("bad"))
+	require.Nil(t, conf.GetDurationSlice("missing"))
+
+	d, err := conf.Get("retry.backoff.1", contract.Duration)
+	require.NoError(t, err)
+	require.Equal(t, 5*time.Second, d)
+}
+
+func TestGetter_GetIntInRange(t *testing.T) {
+	t.Parallel()
+	conf := config.NewGetter(map[string]any{"port": 8080, "low": 0, "high": "70000", "name": "x"})
+
+	v, err := conf.GetIntInRange("port", 1, 65535)
+	require.NoError(t, err)
+	assert.Equal(t, 8080, v)
+
+	v, err = conf.GetIntInRange("port", 8080, 8080)
+	require.NoError(t, err, "bounds are inclusive")
+	assert.Equal(t, 8080, v)
+
+	_, err = conf.GetIntInRange("low", 1, 65535)
+	require.ErrorIs(t, err, configerrors.ErrOutOfRange)
+	assert.Contains(t, err.Error(), `"low" value 0 not in [1, 65535]`)
+
+	_, err = conf.GetIntInRange("high", 1, 65535)
+	require.ErrorIs(t, err, configerrors.ErrOutOfRange)
+	assert.Contains(t, err.Error(), "70000")
+
+	_, err = conf.GetIntInRange("missing", 1, 2)
+	require.ErrorIs(t, err, configerrors.ErrKeyNotFound)
+	_, err = conf.GetIntInRange("name", 1, 2)
+	require.Error(t, err)
+}
+
+func TestGetter_GetFloat64InRange(t *testing.T) {
+	t.Parallel()
+	conf := config.NewGetter(map[string]any{"ratio": 0.5, "over": 1.5, "under": "-0.1"})
+
+	v, err := conf.GetFloat64InRange("ratio", 0, 1)
+	require.NoError(t, err)
+	assert.InDelta(t, 0.5, v, 0.0001)
+
+	_, err = conf.GetFloat64InRange("over", 0, 1)
+	require.ErrorIs(t, err, configerrors.ErrOutOfRange)
+	assert.Contains(t, err.Error(), "1.5")
+
+	_, err = conf.GetFloat64InRange("under", 0, 1)
+	require.ErrorIs(t, err, configerrors.ErrOutOfRange)
+}
+
+func TestGetter_ErrorReturningHelpers(t *testing.T) {
+	t.Parallel()
+	data := baseConfigMap()
+	conf := config.NewGetter(data)
+
+	i, err := conf.GetIntE("foo")
+	require.NoError(t, err)
+	assert.Equal(t, 123, i)
+
+	s, err := conf.GetStringE("bar")
+	require.NoError(t, err)
+	assert.Equal(t, "abc", s)
+
+	b, err := conf.GetBoolE("baz")
+	require.NoError(t, err)
+	assert.True(t, b)
+
+	f, err := conf.GetFloat64E("pi")
+	require.NoError(t, err)
+	assert.InEpsilon(t, 3.14, f, 0.00001)
+
+	i64, err := conf.GetInt64E("nestedint64.v")
+	require.NoError(t, err)
+	assert.Equal(t, int64(777), i64)
+
+	d, err := conf.GetDurationE("duration")
+	require.NoError(t, err)
+	assert.Equal(t, 2*time.Second, d)
+
+	tm, err := conf.GetTimeE("now")
+	require.NoError(t, err)
+	assert.Equal(t, data["now"], tm)
+
+	ss, err := conf.GetStringSliceE("anyslice")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"c", "d"}, ss)
+
+	m, err := conf.GetStringMapE("smap")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"k": "v"}, m)
+
+	_, err = conf.GetIntE("missing")
+	require.ErrorIs(t, err, configerrors.ErrKeyNotFound)
+	_, err = conf.GetIntE("bar")
+	require.ErrorIs(t, err, configerrors.ErrNotInt)
+	_, err = conf.GetBoolE("nested.deep.val")
+	require.ErrorIs(t, err, configerrors.ErrWrongType)
+	_, err = conf.GetStringMapIntE("bar")
+	require.Error(t, err)
+	_, err = conf.GetStringMapStringSliceE("missing")
+	require.ErrorIs(t, err, configerrors.ErrKeyNotFound)
+	_, err = conf.GetDurationSliceE("missing")
+	require.ErrorIs(t, err, configerrors.ErrKeyNotFound)
+}
+
+func TestGetter_Get_NestedConversionErrorKeepsDetail(t *testing.T) {
+	t.Parallel()
+	conf := config.NewGetter(map[string]any{
+		"server": map[string]any{"port": "eighty"},
+	})
+
+	_, err := conf.Get("server.port", contract.Int)
+	require.ErrorIs(t, err, configerrors.ErrWrongType)
+	require.ErrorIs(t, err, configerrors.ErrNotInt)
+
+	var numErr *strconv.NumError
+	require.ErrorAs(t, err, &numErr)
+	assert.Equal(t, "eighty", numErr.Num)
+
+	// Top-level keys take the flat branch and report the same category.
+	_, err = conf.Get("server", contract.Bool)
+	require.ErrorIs(t, err, configerrors.ErrWrongType)
+	require.ErrorIs(t, err, configerrors.ErrNotBool)
+}
+
+func TestGetter_GetStringMap_YAMLInterfaceMap(t *testing.T) {
+	t.Parallel()
+	conf := config.NewGetter(map[string]any{
+		"database": map[interface{}]interface{}{
+			"primary": map[interface{}]interface{}{"host": "db1"},
+		},
+	})
+
+	assert.Equal(t, map[string]any{"host": "db1"}, conf.GetStringMap("database.primary"))
+	assert.Equal(t, map[string]any{"primary": map[string]any{"host": "db1"}}, conf.GetStringMap("database"))
+}
+
+func TestGetter_GetStringSlice_FromDelimitedString(t *testing.T) {
+	t.Parallel()
+	conf := config.NewGetter(map[string]any{
+		"roles": "admin, user",
+		"paths": "/a:/b",
+		"empty": "",
+	})
+
+	// GetStringSlice does not split; GetStringSliceCSV opts in.
+	assert.Nil(t, conf.GetStringSlice("roles"))
+	assert.Equal(t, []string{}, conf.GetStringSliceCSV("empty", ""))
+	assert.Equal(t, []string{"/a", "/b"}, conf.GetStringSliceCSV("paths", ":"))
+	assert.Equal(t, []string{"admin", "user"}, conf.GetStringSliceCSV("roles", ""))
+	assert.Nil(t, conf.GetStringSliceCSV("missing", ","))
+}
+
+func TestConfig_WithValueTransformer(t *testing.T) {
+	t.Parallel()
+
+	// A stand-in for a secret backend: values tagged "enc:" are stored reversed.
+	decrypt := contract.ValueTransformerFunc(func(_ string, raw any) (any, error) {
+		s, ok := raw.(string)
+		if !ok || !strings.HasPrefix(s, "enc:") {
+			return raw, nil
+		}
+
+		runes := []rune(strings.TrimPrefix(s, "enc:"))
+		slices.Reverse(runes)
+
+		return string(runes), nil
+	})
+	trace := contract.ValueTransformerFunc(func(key string, raw any) (any, error) {
+		if key == "db.user" {
+			return fmt.Sprintf("%v (after decrypt)", raw), nil
+		}
+
+		return raw, nil
+	})
+	failing := contract.ValueTransformerFunc(func(key string, raw any) (any, error) {
+		if key == "db.broken" {
+			return nil, errors.New("bad ciphertext")
+		}
+
+		return raw, nil
+	})
+
+	cfg := config.New(
+		config.WithInitialValues(map[string]any{"db": map[string]any{
+			"password": "enc:terces",
+			"user":     "enc:nimda",
+			"port":     5432,
+			"broken":   "enc:??",
+		}}),
+		config.WithValueTransformer(decrypt),
+		config.WithValueTransformer(trace),
+		config.WithValueTransformer(failing),
+	)
+
+	password, err := cfg.Get("db.password", contract.String)
+	require.NoError(t, err)
+	assert.Equal(t, "secret", password)
+
+	user, err := cfg.Get("db.user", contract.String)
+	require.NoError(t, err)
+	assert.Equal(t, "admin (after decrypt)", user)
+
+	port, err := cfg.Get("db.port", contract.Int)
+	require.NoError(t, err)
+	assert.Equal(t, 5432, port)
+
+	_, err = cfg.Get("db.broken", contract.String)
+	require.ErrorContains(t, err, "bad ciphertext")
+}
+
+func TestGetter_GetTimeLayout(t *testing.T) {
+	t.Parallel()
+	now := time.Date(2024, 5, 6, 7, 8, 0, 0, time.UTC)
+	conf := config.NewGetter(map[string]any{
+		"legacy": map[string]any{"started": "2024/05/06 07:08", "bad": "06-05-2024", "num": 5},
+		"native": now,
+	})
+
+	got, err := conf.GetTimeLayout("legacy.started", "2006/01/02 15:04")
+	require.NoError(t, err)
+	assert.Equal(t, now, got)
+
+	got, err = conf.GetTimeLayout("native", "2006/01/02 15:04")
+	require.NoError(t, err)
+	assert.Equal(t, now, got)
+
+	_, err = conf.GetTimeLayout("legacy.bad", "2006/01/02 15:04")
+	require.ErrorIs(t, err, configerrors.ErrNotTime)
+
+	var parseErr *time.ParseError
+	require.ErrorAs(t, err, &parseErr)
+
+	_, err = conf.GetTimeLayout("legacy.num", time.RFC3339)
+	require.ErrorIs(t, err, configerrors.ErrNotTime)
+	_, err = conf.GetTimeLayout("missing", time.RFC3339)
+	require.ErrorIs(t, err, configerrors.ErrKeyNotFound)
+}
+
+func TestGetter_GetDurationUnit(t *testing.T) {
+	t.Parallel()
+	conf := config.NewGetter(map[string]any{
+		"http": map[string]any{
+			"timeout": 30,
+			"grace":   1.5,
+			"idle":    "90",
+			"read":    "1m30s",
+			"bad":     "soon",
+		},
+		"native": 2 * time.Second,
+		"decoded": map[string]any{
+			"count": json.Number("45"),
+			"ratio": json.Number("0.5"),
+		},
+		"huge": map[string]any{
+			"int":    int64(math.MaxInt64 / 2),
+			"uint":   uint64(math.MaxUint64),
+			"float":  1e300,
+			"string": "9000000000000",
+			"number": json.Number("9000000000000"),
+		},
+	})
+
+	cases := []struct {
+		key  string
+		unit time.Duration
+		want time.Duration
+	}{
+		{"http.timeout", time.Second, 30 * time.Second},
+		{"http.timeout", time.Millisecond, 30 * time.Millisecond},
+		{"http.grace", time.Second, 1500 * time.Millisecond},
+		{"http.idle", time.Second, 90 * time.Second},
+		{"http.read", time.Second, 90 * time.Second},
+		{"native", time.Minute, 2 * time.Second},
+		{"decoded.count", time.Second, 45 * time.Second},
+		{"decoded.ratio", time.Second, 500 * time.Millisecond},
+	}
+
+	for _, tc := range cases {
+		got, err := conf.GetDurationUnit(tc.key, tc.unit)
+		require.NoError(t, err, tc.key)
+		assert.Equal(t, tc.want, got, tc.key)
+	}
+
+	_, err := conf.GetDurationUnit("http.bad", time.Second)
+	require.ErrorIs(t, err, configerrors.ErrNotDuration)
+
+	// Counts whose duration does not fit in a time.Duration are rejected
+	// instead of wrapping around.
+	for _, key := range []string{"huge.int", "huge.uint", "huge.float", "huge.string", "huge.number"} {
+		_, err = conf.GetDurationUnit(key, time.Hour)
+		require.ErrorIs(t, err, configerrors.ErrNotDuration, key)
+		require.ErrorContains(t, err, "overflows", key)
+	}
+
+	_, err = conf.GetDurationUnit("http.timeout", -1<<63)
+	require.ErrorIs(t, err, configerrors.ErrNotDuration)
+	_, err = conf.GetDurationUnit("missing", time.Second)
+	require.ErrorIs(t, err, configerrors.ErrKeyNotFound)
+}
+
+func TestConfig_GetFirst(t *testing.T) {
+	t.Parallel()
+	cfg := config.New(config.WithProvider(&fakeProvider{all: map[string]any{
+		"server": map[string]any{"timeout_seconds": "30s", "name": "svc"},
+	}}))
+
+	value, key, err := cfg.GetFirst([]string{"server.timeout", "server.timeout_seconds"}, contract.Duration)
+	require.NoError(t, err)
+	assert.Equal(t, "server.timeout_seconds", key)
+	assert.Equal(t, 30*time.Second, value)
+
+	value, key, err = cfg.GetFirst([]string{"server.name", "server.timeout_seconds"}, contract.String)
+	require.NoError(t, err)
+	assert.Equal(t, "server.name", key)
+	assert.Equal(t, "svc", value)
+
+	_, key, err = cfg.GetFirst([]string{"server.name"}, contract.Duration)
+	require.ErrorIs(t, err, configerrors.ErrWrongType)
+	assert.Equal(t, "server.name", key)
+
+	_, key, err = cfg.GetFirst([]string{"a", "b"}, contract.String)
+	require.ErrorIs(t, err, configerrors.ErrKeyNotFound)
+	assert.Empty(t, key)
+}
+
+func TestGetter_CompositeKeyTypes(t *testing.T) {
+	t.Parallel()
+	conf := config.NewGetter(map[string]any{
+		"retry":   map[string]any{"backoff": []any{"1s", "5s", "30s"}, "bad": []any{"1", "x"}},
+		"ports":   "80, 443",
+		"limits":  map[string]any{"read": "10", "write": 5},
+		"flags":   map[string]any{"beta": "true", "dark": "maybe"},
+		"tiers":   []any{[]any{1, 2}, []any{3}},
+		"scalar":  5,
+		"enabled": map[string]any{"eu": map[string]any{"x": "1"}},
+	})
+
+	value, err := conf.Get("retry.backoff", contract.SliceOf(contract.Duration))
+	require.NoError(t, err)
+	assert.Equal(t, []time.Duration{time.Second, 5 * time.Second, 30 * time.Second}, value)
+
+	// Strings are not split, as for StringSlice; see GetStringSliceCSV.
+	_, err = conf.Get("ports", contract.SliceOf(contract.Int))
+	require.ErrorIs(t, err, configerrors.ErrNotSlice)
+
+	value, err = conf.Get("limits", contract.MapOf(contract.Int64))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int64{"read": 10, "write": 5}, value)
+
+	value, err = conf.Get("tiers", contract.SliceOf(contract.SliceOf(contract.Int)))
+	require.NoError(t, err)
+	assert.Equal(t, [][]int{{1, 2}, {3}}, value)
+
+	value, err = conf.Get("enabled", contract.MapOf(contract.MapOf(contract.Int)))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]map[string]int{"eu": {"x": 1}}, value)
+
+	// Existing named types are composites of their element types.
+	assert.Equal(t, contract.StringSlice, contract.SliceOf(contract.String))
+	assert.Equal(t, contract.DurationSlice, contract.SliceOf(contract.Duration))
+	assert.Equal(t, contract.StringMapInt, contract.MapOf(contract.Int))
+
+	_, err = conf.Get("retry.bad", contract.SliceOf(contract.Int))
+	require.ErrorIs(t, err, configerrors.ErrNotSlice)
+	require.ErrorIs(t, err, configerrors.ErrNotInt)
+	require.ErrorContains(t, err, "element 1")
+
+	_, err = conf.Get("flags", contract.MapOf(contract.Bool))
+	require.ErrorIs(t, err, configerrors.ErrNotMap)
+	require.ErrorIs(t, err, configerrors.ErrNotBool)
+	require.ErrorContains(t, err, `key "dark"`)
+
+	_, err = conf.Get("scalar", contract.SliceOf(contract.Int))
+	require.ErrorIs(t, err, configerrors.ErrNotSlice)
+	_, err = conf.Get("scalar", contract.SliceOf("nope"))
+	require.ErrorIs(t, err, configerrors.ErrUnknownType)
+}
+
+func TestGetter_Lookup(t *testing.T) {
+	t.Parallel()
+	conf := config.NewGetter(map[string]any{"server": map[string]any{"port": "8080", "host": "localhost"}})
+
+	value, found, err := conf.Lookup("server.port", contract.Int)
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, 8080, value)
+
+	value, found, err = conf.Lookup("server.missing", contract.Int)
+	require.NoError(t, err)
+	assert.False(t, found)
+	assert.Nil(t, value)
+
+	_, found, err = conf.Lookup("server.host", contract.Int)
+	assert.True(t, found)
+	require.ErrorIs(t, err, configerrors.ErrWrongType)
+
+	cfg := config.New(config.WithProvider(&fakeProvider{all: map[string]any{"name": "svc"}}))
+	value, found, err = cfg.Lookup("name", contract.String)
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, "svc", value)
+}
+
+func TestGetter_LeafIndexMatchesTraversal(t *testing.T) {
+	t.Parallel()
+
+	conf := config.NewGetter(map[string]any{
+		"server": map[string]any{"Host": "h", "port": 8080, "tags": []any{"a", "b"}, "unset": nil},
+		"ids":    map[string]any{"0": "zero"},
+		"dotted": map[string]any{"a.b": "literal"},
+	})
+
+	// Leaf, structural, case-folded and slice-index paths resolve as before.
+	require.Equal(t, 8080, conf.GetInt("server.port"))
+	require.Equal(t, "h", conf.GetString("server.Host"))
+	require.Equal(t, "h", conf.GetString("SERVER.host"))
+	require.Equal(t, "b", conf.GetString("server.tags.1"))
+	require.Equal(t, []string{"a", "b"}, conf.GetStringSlice("server.tags"))
+	require.True(t, conf.HasKey("server"))
+
+	// Nil leaves, numeric map keys and keys containing dots stay unresolved.
+	require.False(t, conf.HasKey("server.unset"))
+	require.False(t, conf.HasKey("ids.0"))
+	require.False(t, conf.HasKey("dotted.a.b"))
+}
+
+func TestNewGetter_CopiesInput(t *testing.T) {
+	t.Parallel()
+
+	input := map[string]any{
+		"server": map[string]any{"port": 8080, "tags": []any{"a", "b"}},
+	}
+	conf := config.NewGetter(input)
+
+	// Changing the caller's map afterwards is not visible through the Getter.
+	server, _ := input["server"].(map[string]any)
+	server["port"] = 9090
+	server["host"] = "late"
+	server["tags"].([]any)[0] = "z"
+
+	require.Equal(t, 8080, conf.GetInt("server.port"))
+	require.False(t, conf.HasKey("server.host"))
+	require.Equal(t, []string{"a", "b"}, conf.GetStringSlice("server.tags"))
+}
+
+func benchmarkConfigMap() map[string]any {
+	return map[string]any{
+		"app": map[string]any{
+			"name": "demo",
+			"database": map[string]any{
+				"primary": map[string]any{"host": "db.internal", "port": 5432},
+			},
+		},
+	}
+}
+
+func BenchmarkGetter_Get_Leaf(b *testing.B) {
+	conf := config.NewGetter(benchmarkConfigMap())
+
+	for b.Loop() {
+		_, _ = conf.Get("app.database.primary.port", contract.Int)
+	}
+}
+
+func BenchmarkDotmapResolve_Leaf(b *testing.B) {
+	settings := benchmarkConfigMap()
+
+	// The lookup Get performed before leaves were indexed.
+	for b.Loop() {
+		_ = dotmap.Resolve(settings, "app.database.primary.port")
+	}
+}
+
+func BenchmarkGetter_HasKey_Leaf(b *testing.B) {
+	conf := config.NewGetter(benchmarkConfigMap())
+
+	for b.Loop() {
+		_ = conf.HasKey("app.database.primary.host")
+	}
+}
+
+func BenchmarkGetter_HasKey_Structural(b *testing.B) {
+	conf := config.NewGetter(benchmarkConfigMap())
+
+	for b.Loop() {
+		_ = conf.HasKey("app.database.primary")
+	}
+}
+
+func TestGetter_SliceOr(t *testing.T) {
+	t.Parallel()
+
+	conf := config.NewGetter(map[string]any{
+		"roles":  []any{"admin", "user"},
+		"empty":  []any{},
+		"ports":  []any{80, "443"},
+		"ratios": []any{0.5, 1.5},
+		"csv":    "80,443",
+		"name":   map[string]any{"k": "v"},
+	})
+
+	def := []string{"guest"}
+
+	// Missing and unconvertible keys return a copy of the default.
+	missing := conf.GetStringSliceOr("missing", def)
+	require.Equal(t, []string{"guest"}, missing)
+
+	missing[0] = "changed"
+	require.Equal(t, []string{"guest"}, def, "default must not share its backing array")
+	require.Equal(t, def, conf.GetStringSliceOr("name", def))
+	require.Nil(t, conf.GetStringSliceOr("missing", nil))
+
+	// An explicitly empty list is not replaced by the default.
+	require.Equal(t, []string{}, conf.GetStringSliceOr("empty", def))
+	require.Equal(t, []int{}, conf.GetIntSliceOr("empty", []int{1}))
+
+	require.Equal(t, []string{"admin", "user"}, conf.GetStringSliceOr("roles", def))
+	require.Equal(t, []int{80, 443}, conf.GetIntSliceOr("ports", []int{8080}))
+	require.Equal(t, []int{8080}, conf.GetIntSliceOr("roles", []int{8080}))
+	require.Equal(t, []int{8080}, conf.GetIntSliceOr("csv", []int{8080}))
+	require.Equal(t, []float64{0.5, 1.5}, conf.GetFloat64SliceOr("ratios", nil))
+	require.Equal(t, []float64{1.5}, conf.GetFloat64SliceOr("missing", []float64{1.5}))
+}
+
+func TestGetter_GetUUIDAndURL(t *testing.T) {
+	t.Parallel()
+
+	conf := config.NewGetter(baseConfigMap())
+	want := uuid.MustParse("1336301d-4e85-4b76-a2f7-a2fc8ec10888")
+
+	require.Equal(t, want, conf.GetUUID("uuidstr"))
+	require.Equal(t, want, conf.GetUUID("uuid"))
+	require.Equal(t, uuid.Nil, conf.GetUUID("bar"))
+	require.Equal(t, uuid.Nil, conf.GetUUID("missing"))
+
+	require.Equal(t, "https://example.com", conf.GetURL("urlstr").String())
+	require.Equal(t, "https://example.com", conf.GetURL("url").String())
+	require.Nil(t, conf.GetURL("foo"))
+	require.Nil(t, conf.GetURL("missing"))
+}
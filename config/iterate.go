@@ -0,0 +1,125 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/next-trace/scg-config/configerrors"
+	"github.com/next-trace/scg-config/dotmap"
+	"github.com/next-trace/scg-config/utils"
+)
+
+// EachInSlice resolves key to a slice and calls fn for every element in order.
+// It stops at and returns the first error returned by fn. ErrKeyNotFound is
+// returned for missing keys and ErrNotSlice when the value is not a slice.
+func (c *Config) EachInSlice(key string, fn func(index int, value any) error) error {
+	value, ok := c.snapshot().lookup(key)
+	if !ok {
+		return configerrors.ErrKeyNotFound
+	}
+
+	slice := reflect.ValueOf(value)
+	if slice.Kind() != reflect.Slice && slice.Kind() != reflect.Array {
+		return configerrors.ErrNotSlice
+	}
+
+	for i := range slice.Len() {
+		if err := fn(i, slice.Index(i).Interface()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// EachInMap resolves key to a map and calls fn for every entry, visiting keys
+// in sorted order so iteration is deterministic. It stops at and returns the
+// first error returned by fn. ErrKeyNotFound is returned for missing keys and
+// ErrNotMap when the value is not a map.
+func (c *Config) EachInMap(key string, fn func(k string, v any) error) error {
+	value, ok := c.snapshot().lookup(key)
+	if !ok {
+		return configerrors.ErrKeyNotFound
+	}
+
+	mapValue := reflect.ValueOf(value)
+	if mapValue.Kind() != reflect.Map {
+		return configerrors.ErrNotMap
+	}
+
+	entries := make(map[string]any, mapValue.Len())
+	keys := make([]string, 0, mapValue.Len())
+
+	iter := mapValue.MapRange()
+	for iter.Next() {
+		k := fmt.Sprint(iter.Key().Interface())
+		entries[k] = iter.Value().Interface()
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		if err := fn(k, entries[k]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GetAllWithPrefix returns every leaf value under prefix as a flat map keyed by
+// dotted paths relative to prefix, e.g. feature.ui.dark is returned as
+// "ui.dark" for the prefix "feature". Unlike Sub it yields plain data for
+// iteration or serialization. An empty prefix returns the whole configuration;
+// a missing prefix or one that names a leaf returns an empty, non-nil map.
+func (c *Config) GetAllWithPrefix(prefix string) map[string]any {
+	snapshot := c.snapshot()
+	if prefix == "" {
+		return dotmap.Flatten(snapshot.config)
+	}
+
+	value, ok := snapshot.lookup(prefix)
+	if !ok {
+		return map[string]any{}
+	}
+
+	subtree, err := utils.ToMap(value)
+	if err != nil {
+		return map[string]any{}
+	}
+
+	return dotmap.Flatten(subtree)
+}
+
+// HasInSlice reports whether the string list at key contains element, for
+// flag-style settings such as features: [verbose, debug]. The comparison is
+// case-sensitive; see HasInSliceFold. A missing key or a value that is not a
+// list of strings (or a comma-separated string) reports false.
+func (c *Config) HasInSlice(key, element string) bool {
+	return c.hasInSlice(key, func(item string) bool { return item == element })
+}
+
+// HasInSliceFold is like HasInSlice but compares case-insensitively, so
+// "Debug" matches debug.
+func (c *Config) HasInSliceFold(key, element string) bool {
+	return c.hasInSlice(key, func(item string) bool { return strings.EqualFold(item, element) })
+}
+
+// hasInSlice reports whether any element of the string list at key matches.
+func (c *Config) hasInSlice(key string, match func(item string) bool) bool {
+	value, ok := c.snapshot().lookup(key)
+	if !ok {
+		return false
+	}
+
+	items, err := utils.ToStringSliceDelim(value, utils.DefaultListDelimiter)
+	if err != nil {
+		return false
+	}
+
+	return slices.ContainsFunc(items, match)
+}
@@ -0,0 +1,104 @@
+package config_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/next-trace/scg-config/config"
+	"github.com/next-trace/scg-config/configerrors"
+)
+
+func iterateConfig() *config.Config {
+	prov := &fakeProvider{all: map[string]any{
+		"backends": []any{"a", "b", "c"},
+		"features": map[string]any{"zeta": true, "alpha": false, "mid": true},
+		"name":     "svc",
+	}}
+
+	return config.New(config.WithProvider(prov))
+}
+
+func TestConfig_EachInSlice(t *testing.T) {
+	t.Parallel()
+	cfg := iterateConfig()
+
+	var got []any
+	require.NoError(t, cfg.EachInSlice("backends", func(i int, v any) error {
+		require.Len(t, got, i)
+		got = append(got, v)
+		return nil
+	}))
+	require.Equal(t, []any{"a", "b", "c"}, got)
+
+	stop := errors.New("stop")
+	calls := 0
+	err := cfg.EachInSlice("backends", func(int, any) error {
+		calls++
+		return stop
+	})
+	require.ErrorIs(t, err, stop)
+	require.Equal(t, 1, calls)
+
+	require.ErrorIs(t, cfg.EachInSlice("name", func(int, any) error { return nil }), configerrors.ErrNotSlice)
+	require.ErrorIs(t, cfg.EachInSlice("missing", func(int, any) error { return nil }), configerrors.ErrKeyNotFound)
+}
+
+func TestConfig_EachInMap_SortedKeys(t *testing.T) {
+	t.Parallel()
+	cfg := iterateConfig()
+
+	var keys []string
+	require.NoError(t, cfg.EachInMap("features", func(k string, _ any) error {
+		keys = append(keys, k)
+		return nil
+	}))
+	require.Equal(t, []string{"alpha", "mid", "zeta"}, keys)
+
+	require.ErrorIs(t, cfg.EachInMap("backends", func(string, any) error { return nil }), configerrors.ErrNotMap)
+	require.ErrorIs(t, cfg.EachInMap("missing", func(string, any) error { return nil }), configerrors.ErrKeyNotFound)
+}
+
+func TestConfig_GetAllWithPrefix(t *testing.T) {
+	t.Parallel()
+	prov := &fakeProvider{all: map[string]any{
+		"feature": map[string]any{
+			"beta": true,
+			"ui":   map[string]any{"dark": true, "font": "mono"},
+		},
+		"name": "svc",
+	}}
+	cfg := config.New(config.WithProvider(prov))
+
+	require.Equal(t, map[string]any{"beta": true, "ui.dark": true, "ui.font": "mono"}, cfg.GetAllWithPrefix("feature"))
+	require.Equal(t, map[string]any{"dark": true, "font": "mono"}, cfg.GetAllWithPrefix("feature.ui"))
+	require.Len(t, cfg.GetAllWithPrefix(""), 4)
+
+	for _, prefix := range []string{"missing", "name"} {
+		got := cfg.GetAllWithPrefix(prefix)
+		require.NotNil(t, got, prefix)
+		require.Empty(t, got, prefix)
+	}
+}
+
+func TestConfig_HasInSlice(t *testing.T) {
+	t.Parallel()
+	prov := &fakeProvider{all: map[string]any{
+		"features": []any{"verbose", "Debug"},
+		"legacy":   "a, b",
+		"port":     8080,
+		"server":   map[string]any{"host": "x"},
+	}}
+	cfg := config.New(config.WithProvider(prov))
+
+	require.True(t, cfg.HasInSlice("features", "verbose"))
+	require.False(t, cfg.HasInSlice("features", "debug"))
+	require.True(t, cfg.HasInSliceFold("features", "debug"))
+	require.False(t, cfg.HasInSlice("features", "trace"))
+	require.True(t, cfg.HasInSlice("legacy", "b"))
+
+	require.False(t, cfg.HasInSlice("missing", "verbose"))
+	require.False(t, cfg.HasInSlice("port", "8080"))
+	require.False(t, cfg.HasInSliceFold("server", "host"))
+}
@@ -3,9 +3,20 @@ package config
 import (
 	"errors"
 	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/mitchellh/mapstructure"
+
+	"github.com/next-trace/scg-config/configerrors"
+	"github.com/next-trace/scg-config/dotmap"
+	"github.com/next-trace/scg-config/loader/file"
+	"github.com/next-trace/scg-config/provider/viper"
+	"github.com/next-trace/scg-config/utils"
 )
 
 // Load populates the provided struct pointer with values from the current
@@ -13,27 +24,376 @@ import (
 //
 // The decoding respects `mapstructure` tags on the target struct. After
 // decoding, fields are validated using github.com/go-playground/validator
-// according to any `validate` tags present. If validation fails, a detailed
-// error describing invalid fields is returned.
+// according to any `validate` tags present, including cross-field rules such
+// as `validate:"required_if=Enabled true"` for settings that are only needed
+// when another one is set. If validation fails, a detailed error naming each
+// invalid field by its full namespace (e.g. AppConfig.TLS.Cert) and the failed
+// rule is returned.
+//
+// Decoding is weakly typed, so string values (e.g. from environment variables)
+// are converted to numbers and booleans. In addition:
+//   - time.Duration fields accept duration strings such as "30s" or "1m30s";
+//     plain integers are interpreted as nanoseconds.
+//   - time.Time fields accept RFC 3339 strings such as "2024-01-02T15:04:05Z".
+//   - Slice fields (e.g. []string or []int) accept comma-separated strings, as
+//     environment variables deliver them: APP_ROLES="a, b,c" decodes into
+//     []string{"a", "b", "c"}. []byte fields are not split.
+//   - Pointer fields (e.g. *int) stay nil when their key is absent or null,
+//     which makes them the natural way to model optional settings. Use
+//     `validate:"omitempty,..."` on them so rules apply only when a value is set.
+//
+// The decoded data is built in a fixed order: the provider settings (files,
+// defaults and whatever the provider itself layers on), then, when the Config
+// was created with WithEnvOverlay, the matching environment variables on top.
 func (c *Config) Load(out any) error { //nolint:ireturn // returning error (an interface) is idiomatic Go
 	if out == nil {
 		return fmt.Errorf("config: output target is nil")
 	}
 
-	// Decode the provider settings map into the target struct.
+	return decodeAndValidate(c.loadSettings(), out, "")
+}
+
+// LoadWithWarnings behaves like Load and additionally reports every value that
+// weak typing had to coerce across types, such as the string "8080" decoded
+// into an int field. Each warning names the field and both types, e.g.
+//
+//	'server.port' expected type 'int', got unconvertible type 'string', value: '8080'
+//
+// Conversions done by the documented hooks (duration and RFC 3339 strings) are
+// not reported. Warnings are only returned when Load succeeds.
+func (c *Config) LoadWithWarnings(out any) ([]string, error) {
+	if out == nil {
+		return nil, fmt.Errorf("config: output target is nil")
+	}
+
+	settings := c.loadSettings()
+	if err := decodeAndValidate(settings, out, ""); err != nil {
+		return nil, err
+	}
+
+	return coercions(settings, out), nil
+}
+
+// FieldError describes a field that LoadLenient could not decode.
+type FieldError struct {
+	// Field is the mapstructure path of the field, e.g. "server.port".
+	Field string
+	// Message is the decoder's description of the failure.
+	Message string
+}
+
+// Error returns the decoder's description of the failure.
+func (e FieldError) Error() string { return e.Message }
+
+// LoadLenient decodes like Load but does not give up on fields whose values
+// cannot be converted, such as "abc" for an int: every other field is still
+// populated, failing fields keep their previous value (zero unless preset) and
+// are returned as FieldErrors sorted by field. Only structural problems, such
+// as a nil or non-pointer target or a configuration that is not a map, are
+// returned as an error. Validation tags are not checked, since skipped fields
+// would fail them; call a validator on out if needed.
+func (c *Config) LoadLenient(out any) ([]FieldError, error) {
+	target := reflect.ValueOf(out)
+	if out == nil || target.Kind() != reflect.Pointer || target.IsNil() {
+		return nil, fmt.Errorf("config: output target must be a non-nil pointer")
+	}
+
+	decoder, err := newDecoder(out, true)
+	if err != nil {
+		return nil, err
+	}
+
+	decodeErr := decoder.Decode(c.loadSettings())
+	if decodeErr == nil {
+		return nil, nil
+	}
+
+	var mapErr *mapstructure.Error
+	if !errors.As(decodeErr, &mapErr) {
+		return nil, fmt.Errorf("config: failed to unmarshal config into struct: %w", decodeErr)
+	}
+
+	fieldErrors := make([]FieldError, 0, len(mapErr.Errors))
+
+	for _, msg := range mapErr.Errors {
+		field := quotedField(msg)
+		if field == "" {
+			return nil, fmt.Errorf("config: failed to unmarshal config into struct: %w", decodeErr)
+		}
+
+		fieldErrors = append(fieldErrors, FieldError{Field: field, Message: msg})
+	}
+
+	sort.Slice(fieldErrors, func(i, j int) bool { return fieldErrors[i].Field < fieldErrors[j].Field })
+
+	return fieldErrors, nil
+}
+
+// quotedField returns the field path mapstructure quotes first in msg, e.g.
+// server.port in "cannot parse 'server.port' as int: ...", or "" for errors
+// about the input as a whole.
+func quotedField(msg string) string {
+	_, rest, ok := strings.Cut(msg, "'")
+	if !ok {
+		return ""
+	}
+
+	field, _, _ := strings.Cut(rest, "'")
+
+	return field
+}
+
+// LoadKey decodes the sub-tree at key into out and validates it, using the
+// same decoding rules as Load. It returns ErrKeyNotFound when key is missing.
+//
+// out may also point to a slice of structs, e.g. *[]ServerConfig for a list
+// under "servers". Each element is validated and failures name the element
+// index and the mapstructure field name, e.g. "servers[1].port".
+func (c *Config) LoadKey(key string, out any) error {
+	if out == nil {
+		return fmt.Errorf("config: output target is nil")
+	}
+
+	return decodeKey(c.loadSettings(), key, out)
+}
+
+// decodeKey decodes the sub-tree of settings at key into out like LoadKey.
+func decodeKey(settings map[string]any, key string, out any) error {
+	value := dotmap.Resolve(settings, key)
+	if value == nil {
+		return fmt.Errorf("%w: %s", configerrors.ErrKeyNotFound, key)
+	}
+
+	return decodeAndValidate(value, out, key)
+}
+
+// LoadFlat decodes every key starting with prefix into out, with the prefix
+// stripped, and validates it like Load. The prefix is matched against dotted
+// paths case-insensitively and used verbatim, so "db." gathers db.host and
+// db.pool.size (decoded as host and pool.size) whether the keys are nested or
+// stored flat with dots, and "db_" gathers flat env-style keys such as
+// db_host. A prefix without a trailing separator, such as "db", gathers keys
+// continuing with either "." or "_" and skips unrelated ones like dbname. It
+// returns ErrKeyNotFound when no key matches.
+func (c *Config) LoadFlat(prefix string, out any) error {
+	if out == nil {
+		return fmt.Errorf("config: output target is nil")
+	}
+
+	prefix = strings.ToLower(prefix)
+	separated := strings.HasSuffix(prefix, ".") || strings.HasSuffix(prefix, "_")
+	input := map[string]any{}
+
+	for key, value := range dotmap.Flatten(c.loadSettings()) {
+		rest, ok := strings.CutPrefix(strings.ToLower(key), prefix)
+		if ok && !separated {
+			rest, ok = cutFlatSeparator(rest)
+		}
+
+		if !ok || rest == "" {
+			continue
+		}
+
+		input = setPath(input, strings.Split(rest, "."), value)
+	}
+
+	if len(input) == 0 {
+		return fmt.Errorf("%w: %s*", configerrors.ErrKeyNotFound, prefix)
+	}
+
+	return decodeAndValidate(input, out, strings.TrimRight(prefix, "._"))
+}
+
+// cutFlatSeparator strips the leading "." or "_" of rest, reporting whether
+// there was one.
+func cutFlatSeparator(rest string) (string, bool) {
+	if rest == "" || (rest[0] != '.' && rest[0] != '_') {
+		return rest, false
+	}
+
+	return rest[1:], true
+}
+
+// LoadMap returns the whole configuration as a generic tree, with the same
+// settings Load would decode. The result is a deep copy in which every
+// map[interface{}]interface{} (as produced by some YAML decoders) has been
+// converted to map[string]any, so it can be marshaled to JSON directly.
+func (c *Config) LoadMap() (map[string]any, error) {
+	normalized, ok := utils.NormalizeMap(c.loadSettings()).(map[string]any)
+	if !ok {
+		return nil, configerrors.ErrNotMap
+	}
+
+	return normalized, nil
+}
+
+// AllSettingsSorted returns the provider settings as a dotmap.OrderedMap with
+// map keys sorted recursively and slices left in their original order. It
+// marshals to JSON and YAML deterministically, e.g. for golden-file tests and
+// config dumps.
+func (c *Config) AllSettingsSorted() dotmap.OrderedMap {
+	return dotmap.Sorted(c.provider.AllSettings())
+}
+
+// loadSettings returns the provider settings with the env overlay applied when
+// one is configured.
+func (c *Config) loadSettings() map[string]any {
+	return c.withEnvOverlay(c.provider.AllSettings())
+}
+
+// withEnvOverlay returns settings with the env overlay applied when one is
+// configured. Maps along overlaid paths are copied, so settings is not modified.
+func (c *Config) withEnvOverlay(settings map[string]any) map[string]any {
+	if c.envOverlay == nil {
+		return settings
+	}
+
+	prefix := utils.NormalizePrefix(*c.envOverlay)
+
+	for _, envString := range os.Environ() {
+		if !utils.ShouldProcessEnv(envString, prefix) {
+			continue
+		}
+
+		envName, value := utils.SplitEnv(envString)
+		key := utils.NormalizeEnvKey(utils.StripPrefix(envName, prefix))
+		if key == "" {
+			continue
+		}
+
+		settings = setPath(settings, strings.Split(key, "."), value)
+	}
+
+	return settings
+}
+
+// setPath returns a copy of settings with value stored at path, creating
+// intermediate maps as needed. Maps along the path are copied so the
+// provider's own data is never modified.
+func setPath(settings map[string]any, path []string, value any) map[string]any {
+	result := make(map[string]any, len(settings)+1)
+	for key, existing := range settings {
+		result[key] = existing
+	}
+
+	if len(path) == 1 {
+		result[path[0]] = value
+
+		return result
+	}
+
+	child, _ := result[path[0]].(map[string]any)
+	result[path[0]] = setPath(child, path[1:], value)
+
+	return result
+}
+
+// LoadPreview loads the candidate file at path into a temporary provider,
+// decodes and validates it into out and returns the changes it would make
+// relative to the live settings. The live configuration is never modified, so
+// a reload can be validated first and applied only when valid.
+func (c *Config) LoadPreview(path string, out any) ([]Change, error) {
+	if out == nil {
+		return nil, fmt.Errorf("config: output target is nil")
+	}
+
+	candidate := viper.NewConfigProvider()
+	if err := file.NewFileLoader(candidate).LoadFromFile(path); err != nil {
+		return nil, fmt.Errorf("config: failed to load preview file: %w", err)
+	}
+
+	settings := candidate.AllSettings()
+	if err := decodeAndValidate(settings, out, ""); err != nil {
+		return nil, err
+	}
+
+	return Diff(c.provider.AllSettings(), settings), nil
+}
+
+// decodeAndValidate decodes input into out and validates the result. key names
+// the decoded sub-tree and prefixes validation errors of slice elements.
+func decodeAndValidate(input, out any, key string) error {
+	// Decode the provider settings into the target.
+	decoder, err := newDecoder(out, true)
+	if err != nil {
+		return err
+	}
+	if err := decoder.Decode(input); err != nil {
+		return fmt.Errorf("config: failed to unmarshal config into struct: %w", err)
+	}
+
+	target := reflect.Indirect(reflect.ValueOf(out))
+
+	switch target.Kind() {
+	case reflect.Struct:
+		return validateStruct(out)
+	case reflect.Slice, reflect.Array:
+		return validateElements(target, key)
+	default:
+		// Only structs carry `validate` tags; other targets are returned as decoded.
+		return nil
+	}
+}
+
+// newDecoder returns the decoder used by Load for out. weak enables
+// mapstructure's weakly typed input (string "8080" into an int, etc.).
+func newDecoder(out any, weak bool) (*mapstructure.Decoder, error) {
 	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
 		TagName:          "mapstructure",
 		Result:           out,
-		WeaklyTypedInput: true,
+		WeaklyTypedInput: weak,
+		DecodeHook: mapstructure.ComposeDecodeHookFunc(
+			mapstructure.StringToTimeDurationHookFunc(),
+			mapstructure.StringToTimeHookFunc(time.RFC3339),
+			stringToSliceHook,
+		),
 	})
 	if err != nil {
-		return fmt.Errorf("config: failed to create decoder: %w", err)
+		return nil, fmt.Errorf("config: failed to create decoder: %w", err)
 	}
-	if err := decoder.Decode(c.provider.AllSettings()); err != nil {
-		return fmt.Errorf("config: failed to unmarshal config into struct: %w", err)
+
+	return decoder, nil
+}
+
+// stringToSliceHook splits a string decoded into a slice (other than []byte)
+// on commas, so list settings can come from environment variables. Elements
+// are then converted to the slice's element type by the decoder.
+func stringToSliceHook(from, to reflect.Type, data any) (any, error) {
+	if from.Kind() != reflect.String || to.Kind() != reflect.Slice || to.Elem().Kind() == reflect.Uint8 {
+		return data, nil
+	}
+
+	text, _ := data.(string)
+
+	return utils.SplitList(text, utils.DefaultListDelimiter), nil
+}
+
+// coercions decodes input strictly into a scratch value of out's type and
+// returns, sorted, the mismatches that weak typing had to paper over.
+func coercions(input, out any) []string {
+	target := reflect.TypeOf(out)
+	if target.Kind() != reflect.Ptr {
+		return nil
 	}
 
-	// Validate the populated struct using `validate` tags.
+	decoder, err := newDecoder(reflect.New(target.Elem()).Interface(), false)
+	if err != nil {
+		return nil
+	}
+
+	var decodeErr *mapstructure.Error
+	if !errors.As(decoder.Decode(input), &decodeErr) {
+		return nil
+	}
+
+	warnings := append([]string(nil), decodeErr.Errors...)
+	sort.Strings(warnings)
+
+	return warnings
+}
+
+// validateStruct validates a populated struct using `validate` tags.
+func validateStruct(out any) error {
 	configValidator := validator.New(validator.WithRequiredStructEnabled())
 	if err := configValidator.Struct(out); err != nil {
 		var validationErrors validator.ValidationErrors
@@ -42,11 +402,7 @@ func (c *Config) Load(out any) error { //nolint:ireturn // returning error (an i
 			msg := "config validation failed:"
 			for _, fieldError := range validationErrors {
 				// fieldError.Namespace() gives full path; fieldError.Field() gives field name.
-				msg += fmt.Sprintf(" field '%s' failed '%s'", fieldError.Namespace(), fieldError.Tag())
-				if fieldError.Param() != "" {
-					msg += fmt.Sprintf("='%s'", fieldError.Param())
-				}
-				msg += ";"
+				msg += describeFieldError(fieldError.Namespace(), fieldError)
 			}
 			return errors.New(msg)
 		}
@@ -57,3 +413,58 @@ func (c *Config) Load(out any) error { //nolint:ireturn // returning error (an i
 
 	return nil
 }
+
+// validateElements validates every struct element of a decoded slice, naming
+// failing fields by key, element index and mapstructure name (servers[1].port).
+func validateElements(target reflect.Value, key string) error {
+	configValidator := validator.New(validator.WithRequiredStructEnabled())
+	configValidator.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name, _, _ := strings.Cut(field.Tag.Get("mapstructure"), ",")
+		if name == "" {
+			return field.Name
+		}
+
+		return name
+	})
+
+	msg := ""
+
+	for i := range target.Len() {
+		elem := reflect.Indirect(target.Index(i))
+		if elem.Kind() != reflect.Struct {
+			continue
+		}
+
+		err := configValidator.Struct(elem.Interface())
+		if err == nil {
+			continue
+		}
+
+		var validationErrors validator.ValidationErrors
+		if !errors.As(err, &validationErrors) {
+			return fmt.Errorf("config validation error: %s[%d]: %w", key, i, err)
+		}
+
+		for _, fieldError := range validationErrors {
+			// Replace the element's type name with its position in the list.
+			_, field, _ := strings.Cut(fieldError.Namespace(), ".")
+			msg += describeFieldError(fmt.Sprintf("%s[%d].%s", key, i, field), fieldError)
+		}
+	}
+
+	if msg == "" {
+		return nil
+	}
+
+	return errors.New("config validation failed:" + msg)
+}
+
+// describeFieldError formats a single field error for a validation message.
+func describeFieldError(path string, fieldError validator.FieldError) string {
+	msg := fmt.Sprintf(" field '%s' failed '%s'", path, fieldError.Tag())
+	if fieldError.Param() != "" {
+		msg += fmt.Sprintf("='%s'", fieldError.Param())
+	}
+
+	return msg + ";"
+}
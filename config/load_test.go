@@ -1,11 +1,17 @@
 package config_test
 
 import (
+	"encoding/json"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
 	"github.com/next-trace/scg-config/config"
+	"github.com/next-trace/scg-config/configerrors"
+	"github.com/next-trace/scg-config/contract"
 	"github.com/next-trace/scg-config/provider/viper"
 )
 
@@ -88,3 +94,472 @@ func TestConfig_Load_NonPointer_ReturnsError(t *testing.T) {
 	err := cfg.Load(out) // intentionally not &out
 	require.Error(t, err)
 }
+
+func TestConfig_Load_DurationAndTimeForms(t *testing.T) {
+	t.Parallel()
+
+	type timeouts struct {
+		Timeout time.Duration `mapstructure:"timeout"`
+		Started time.Time     `mapstructure:"started"`
+	}
+
+	tests := []struct {
+		name    string
+		timeout any
+		want    time.Duration
+	}{
+		{"duration string", "30s", 30 * time.Second},
+		{"compound duration string", "1m30s", 90 * time.Second},
+		{"integer nanoseconds", 1500, 1500 * time.Nanosecond},
+		{"int64 nanoseconds", int64(time.Second), time.Second},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			prov := viper.NewConfigProvider()
+			prov.Set("timeout", testCase.timeout)
+			prov.Set("started", "2024-01-02T15:04:05Z")
+			cfg := config.New(config.WithProvider(prov))
+
+			var out timeouts
+			require.NoError(t, cfg.Load(&out))
+			require.Equal(t, testCase.want, out.Timeout)
+			require.Equal(t, time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC), out.Started)
+		})
+	}
+}
+
+func TestConfig_Load_InvalidDurationString(t *testing.T) {
+	t.Parallel()
+
+	prov := viper.NewConfigProvider()
+	prov.Set("timeout", "soon")
+	cfg := config.New(config.WithProvider(prov))
+
+	var out struct {
+		Timeout time.Duration `mapstructure:"timeout"`
+	}
+	require.Error(t, cfg.Load(&out))
+}
+
+func TestConfig_LoadPreview(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	live := filepath.Join(dir, "live.yaml")
+	require.NoError(t, os.WriteFile(live, []byte("app:\n  name: Live\nserver:\n  port: 8080\n  debug: true\n"), 0o600))
+	next := filepath.Join(dir, "next.yaml")
+	require.NoError(t, os.WriteFile(next, []byte("app:\n  name: Next\nserver:\n  port: 8080\n  tls: true\n"), 0o600))
+
+	cfg := config.New()
+	require.NoError(t, cfg.FileLoader().LoadFromFile(live))
+	require.NoError(t, cfg.Reload())
+
+	var out appConfig
+	changes, err := cfg.LoadPreview(next, &out)
+	require.NoError(t, err)
+	require.Equal(t, "Next", out.App.Name)
+	require.Equal(t, []config.Change{
+		{Key: "app.name", Old: "Live", New: "Next"},
+		{Key: "server.debug", Old: true, New: nil},
+		{Key: "server.tls", Old: nil, New: true},
+	}, changes)
+
+	// The live configuration is untouched.
+	name, err := cfg.Get("app.name", contract.String)
+	require.NoError(t, err)
+	require.Equal(t, "Live", name)
+}
+
+func TestConfig_LoadPreview_ValidationFailureLeavesLiveConfig(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	live := filepath.Join(dir, "live.yaml")
+	require.NoError(t, os.WriteFile(live, []byte("app:\n  name: Live\nserver:\n  port: 8080\n"), 0o600))
+	bad := filepath.Join(dir, "bad.yaml")
+	require.NoError(t, os.WriteFile(bad, []byte("app:\n  name: X\nserver:\n  port: 0\n"), 0o600))
+
+	cfg := config.New()
+	require.NoError(t, cfg.FileLoader().LoadFromFile(live))
+
+	var out appConfig
+	_, err := cfg.LoadPreview(bad, &out)
+	require.Error(t, err)
+	require.Equal(t, "Live", cfg.Provider().GetKey("app.name"))
+
+	_, err = cfg.LoadPreview(filepath.Join(dir, "missing.yaml"), &out)
+	require.Error(t, err)
+}
+
+func TestConfig_ReloadValidated_RejectsBadConfig(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "app.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("app:\n  name: Good\nserver:\n  port: 8080\n"), 0o600))
+
+	cfg := config.New()
+	require.NoError(t, cfg.FileLoader().LoadFromFile(path))
+
+	var out appConfig
+	require.NoError(t, cfg.ReloadValidated(&out))
+	require.Equal(t, "Good", out.App.Name)
+
+	// The file changes to an invalid configuration that also adds a key.
+	require.NoError(t, os.WriteFile(path, []byte("app:\n  name: Bad\n  debug: true\nserver:\n  port: 0\n"), 0o600))
+	require.Error(t, cfg.ReloadValidated(&out))
+
+	// Previous values are retained everywhere.
+	require.Equal(t, "Good", out.App.Name)
+	require.Equal(t, 8080, out.Server.Port)
+	name, err := cfg.Get("app.name", contract.String)
+	require.NoError(t, err)
+	require.Equal(t, "Good", name)
+	require.Equal(t, "Good", cfg.Provider().GetKey("app.name"))
+	require.False(t, cfg.Provider().IsSet("app.debug"))
+	require.False(t, cfg.Has("app.debug"))
+
+	// A subsequent valid change is applied.
+	require.NoError(t, os.WriteFile(path, []byte("app:\n  name: Better\nserver:\n  port: 9090\n"), 0o600))
+	require.NoError(t, cfg.ReloadValidated(&out))
+	require.Equal(t, 9090, out.Server.Port)
+	port, err := cfg.Get("server.port", contract.Int)
+	require.NoError(t, err)
+	require.Equal(t, 9090, port)
+}
+
+func TestConfig_ReloadValidated_InvalidTarget(t *testing.T) {
+	t.Parallel()
+	cfg := config.New()
+	require.Error(t, cfg.ReloadValidated(nil))
+	require.Error(t, cfg.ReloadValidated(appConfig{}))
+}
+
+func TestConfig_Load_EnvOverlay(t *testing.T) {
+	// Note: Cannot use t.Parallel() with t.Setenv()
+	t.Setenv("OVERLAYTEST_SERVER_PORT", "9090")
+	t.Setenv("OVERLAYTEST_APP_NAME", "FromEnv")
+
+	settings := map[string]any{
+		"app":    map[string]any{"name": "FromFile"},
+		"server": map[string]any{"port": 8080},
+	}
+
+	// The fake provider knows nothing about the environment.
+	plain := config.New(config.WithProvider(&fakeProvider{all: settings}))
+
+	var out appConfig
+	require.NoError(t, plain.Load(&out))
+	require.Equal(t, "FromFile", out.App.Name)
+	require.Equal(t, 8080, out.Server.Port)
+
+	overlaid := config.New(
+		config.WithProvider(&fakeProvider{all: settings}),
+		config.WithEnvOverlay("overlaytest"),
+	)
+	require.NoError(t, overlaid.Load(&out))
+	require.Equal(t, "FromEnv", out.App.Name)
+	require.Equal(t, 9090, out.Server.Port)
+
+	var server struct {
+		Port int `mapstructure:"port"`
+	}
+	require.NoError(t, overlaid.LoadKey("server", &server))
+	require.Equal(t, 9090, server.Port)
+
+	// The provider's own data is left untouched.
+	require.Equal(t, 8080, settings["server"].(map[string]any)["port"])
+}
+
+func TestConfig_ReloadValidated_EnvOverlay(t *testing.T) {
+	// Note: Cannot use t.Parallel() with t.Setenv()
+	t.Setenv("RVOVERLAY_SERVER_PORT", "9090")
+
+	// The file value alone fails validation; the overlaid one passes.
+	settings := map[string]any{
+		"app":    map[string]any{"name": "FromFile"},
+		"server": map[string]any{"port": 0},
+	}
+
+	var out appConfig
+	plain := config.New(config.WithProvider(&fakeProvider{all: settings}))
+	require.Error(t, plain.ReloadValidated(&out))
+
+	overlaid := config.New(
+		config.WithProvider(&fakeProvider{all: settings}),
+		config.WithEnvOverlay("rvoverlay"),
+	)
+	require.NoError(t, overlaid.ReloadValidated(&out))
+	require.Equal(t, "FromFile", out.App.Name)
+	require.Equal(t, 9090, out.Server.Port)
+}
+
+func TestConfig_LoadMap_NormalizesNestedMaps(t *testing.T) {
+	t.Parallel()
+	prov := &fakeProvider{all: map[string]any{
+		"database": map[interface{}]interface{}{
+			"replicas": []any{map[interface{}]interface{}{"host": "r1"}},
+		},
+		"app": map[string]any{"name": "demo"},
+	}}
+	cfg := config.New(config.WithProvider(prov))
+
+	tree, err := cfg.LoadMap()
+	require.NoError(t, err)
+	require.Equal(t, map[string]any{
+		"database": map[string]any{"replicas": []any{map[string]any{"host": "r1"}}},
+		"app":      map[string]any{"name": "demo"},
+	}, tree)
+
+	_, err = json.Marshal(tree)
+	require.NoError(t, err)
+
+	// The result is a copy.
+	tree["app"].(map[string]any)["name"] = "changed"
+	require.Equal(t, "demo", prov.all["app"].(map[string]any)["name"])
+}
+
+func TestConfig_Load_OptionalPointerFields(t *testing.T) {
+	t.Parallel()
+
+	type poolConfig struct {
+		Name     *string        `mapstructure:"name" validate:"omitempty,min=3"`
+		MaxConns *int           `mapstructure:"max_conns" validate:"omitempty,min=1"`
+		Timeout  *time.Duration `mapstructure:"timeout"`
+		Enabled  *bool          `mapstructure:"enabled"`
+	}
+
+	// Absent keys leave pointers nil and do not trip validation.
+	absent := config.New(config.WithProvider(&fakeProvider{all: map[string]any{}}))
+
+	var out poolConfig
+	require.NoError(t, absent.Load(&out))
+	require.Nil(t, out.Name)
+	require.Nil(t, out.MaxConns)
+	require.Nil(t, out.Timeout)
+	require.Nil(t, out.Enabled)
+
+	// Present keys are decoded, including zero values such as false.
+	present := config.New(config.WithProvider(&fakeProvider{all: map[string]any{
+		"name": "primary", "max_conns": "10", "timeout": "5s", "enabled": false,
+	}}))
+
+	var set poolConfig
+	require.NoError(t, present.Load(&set))
+	require.Equal(t, "primary", *set.Name)
+	require.Equal(t, 10, *set.MaxConns)
+	require.Equal(t, 5*time.Second, *set.Timeout)
+	require.False(t, *set.Enabled)
+
+	// Explicit null is treated like absence.
+	null := config.New(config.WithProvider(&fakeProvider{all: map[string]any{"max_conns": nil}}))
+
+	var nulled poolConfig
+	require.NoError(t, null.Load(&nulled))
+	require.Nil(t, nulled.MaxConns)
+
+	// Rules still apply once a value is present.
+	invalid := config.New(config.WithProvider(&fakeProvider{all: map[string]any{"max_conns": 0}}))
+
+	var bad poolConfig
+	err := invalid.Load(&bad)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "MaxConns")
+}
+
+func TestConfig_AllSettingsSorted(t *testing.T) {
+	t.Parallel()
+	prov := &fakeProvider{all: map[string]any{
+		"server": map[string]any{"port": 8080, "host": "h"},
+		"app":    map[string]any{"roles": []any{"z", "a"}},
+	}}
+	cfg := config.New(config.WithProvider(prov))
+
+	out, err := json.Marshal(cfg.AllSettingsSorted())
+	require.NoError(t, err)
+	require.JSONEq(t, `{"app":{"roles":["z","a"]},"server":{"host":"h","port":8080}}`, string(out))
+	require.Equal(t, `{"app":{"roles":["z","a"]},"server":{"host":"h","port":8080}}`, string(out))
+}
+
+func TestConfig_LoadWithWarnings(t *testing.T) {
+	t.Parallel()
+	prov := &fakeProvider{all: map[string]any{
+		"app":     map[string]any{"name": "demo"},
+		"server":  map[string]any{"port": "8080"},
+		"timeout": "30s",
+	}}
+	cfg := config.New(config.WithProvider(prov))
+
+	var out struct {
+		appConfig `mapstructure:",squash"`
+		Timeout   time.Duration `mapstructure:"timeout"`
+	}
+
+	warnings, err := cfg.LoadWithWarnings(&out)
+	require.NoError(t, err)
+	require.Equal(t, 8080, out.Server.Port)
+	require.Equal(t, 30*time.Second, out.Timeout)
+	require.Len(t, warnings, 1)
+	require.Contains(t, warnings[0], "'server.port' expected type 'int'")
+	require.Contains(t, warnings[0], "'string'")
+
+	// Exactly typed input produces no warnings.
+	typed := config.New(config.WithProvider(&fakeProvider{all: map[string]any{
+		"app": map[string]any{"name": "demo"}, "server": map[string]any{"port": 8080},
+	}}))
+	warnings, err = typed.LoadWithWarnings(&out)
+	require.NoError(t, err)
+	require.Empty(t, warnings)
+
+	_, err = cfg.LoadWithWarnings(nil)
+	require.Error(t, err)
+}
+
+func TestConfig_Load_CommaSeparatedEnvList(t *testing.T) {
+	// Note: Cannot use t.Parallel() with t.Setenv()
+	t.Setenv("LISTTEST_ROLES", "admin, editor,viewer")
+	t.Setenv("LISTTEST_PORTS", "80,443")
+
+	cfg := config.New()
+	require.NoError(t, cfg.EnvLoader().LoadFromEnv("LISTTEST"))
+
+	var out struct {
+		Roles []string `mapstructure:"roles"`
+		Ports []int    `mapstructure:"ports"`
+	}
+	require.NoError(t, cfg.Load(&out))
+	require.Equal(t, []string{"admin", "editor", "viewer"}, out.Roles)
+	require.Equal(t, []int{80, 443}, out.Ports)
+}
+
+func TestConfig_LoadLenient(t *testing.T) {
+	t.Parallel()
+	cfg := config.New(config.WithProvider(&fakeProvider{all: map[string]any{
+		"app":    map[string]any{"name": "svc", "debug": "maybe"},
+		"server": map[string]any{"port": "abc", "host": "localhost", "timeout": "5s"},
+	}}))
+
+	out := struct {
+		App struct {
+			Name  string `mapstructure:"name"`
+			Debug bool   `mapstructure:"debug"`
+		} `mapstructure:"app"`
+		Server struct {
+			Port    int           `mapstructure:"port"`
+			Host    string        `mapstructure:"host"`
+			Timeout time.Duration `mapstructure:"timeout"`
+		} `mapstructure:"server"`
+	}{}
+	out.Server.Port = 8080
+
+	fieldErrors, err := cfg.LoadLenient(&out)
+	require.NoError(t, err)
+	require.Len(t, fieldErrors, 2)
+	require.Equal(t, "app.debug", fieldErrors[0].Field)
+	require.Equal(t, "server.port", fieldErrors[1].Field)
+	require.Contains(t, fieldErrors[1].Error(), "abc")
+
+	require.Equal(t, "svc", out.App.Name)
+	require.False(t, out.App.Debug)
+	require.Equal(t, 8080, out.Server.Port)
+	require.Equal(t, "localhost", out.Server.Host)
+	require.Equal(t, 5*time.Second, out.Server.Timeout)
+
+	_, err = cfg.LoadLenient(nil)
+	require.Error(t, err)
+
+	var notStruct int
+	_, err = cfg.LoadLenient(&notStruct)
+	require.Error(t, err)
+}
+
+type tlsConfig struct {
+	TLS struct {
+		Enabled bool   `mapstructure:"enabled"`
+		Cert    string `mapstructure:"cert" validate:"required_if=Enabled true"`
+		Mode    string `mapstructure:"mode" validate:"required_unless=Enabled false"`
+	} `mapstructure:"tls"`
+}
+
+func TestConfig_Load_ConditionalValidation(t *testing.T) {
+	t.Parallel()
+
+	disabled := config.New(config.WithInitialValues(map[string]any{
+		"tls": map[string]any{"enabled": false},
+	}))
+
+	var out tlsConfig
+	require.NoError(t, disabled.Load(&out))
+
+	enabled := config.New(config.WithInitialValues(map[string]any{
+		"tls": map[string]any{"enabled": true},
+	}))
+
+	err := enabled.Load(&out)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "field 'tlsConfig.TLS.Cert' failed 'required_if'='Enabled true'")
+	require.Contains(t, err.Error(), "field 'tlsConfig.TLS.Mode' failed 'required_unless'='Enabled false'")
+
+	complete := config.New(config.WithInitialValues(map[string]any{
+		"tls": map[string]any{"enabled": true, "cert": "/etc/tls/cert.pem", "mode": "strict"},
+	}))
+	require.NoError(t, complete.Load(&out))
+}
+
+type dbConfig struct {
+	Host string `mapstructure:"host" validate:"required"`
+	Port int    `mapstructure:"port" validate:"min=1"`
+	Pool struct {
+		Size int `mapstructure:"size"`
+	} `mapstructure:"pool"`
+}
+
+func TestConfig_LoadFlat(t *testing.T) {
+	t.Parallel()
+
+	nested := config.New(config.WithInitialValues(map[string]any{
+		"db":    map[string]any{"host": "db.internal", "port": "5432", "pool": map[string]any{"size": 10}},
+		"cache": map[string]any{"host": "cache.internal"},
+	}))
+
+	var out dbConfig
+	require.NoError(t, nested.LoadFlat("db.", &out))
+	require.Equal(t, "db.internal", out.Host)
+	require.Equal(t, 5432, out.Port)
+	require.Equal(t, 10, out.Pool.Size)
+
+	flat := config.New(config.WithInitialValues(map[string]any{
+		"DB_HOST": "flat.internal",
+		"db_port": 6543,
+		"other":   "x",
+	}))
+
+	out = dbConfig{}
+	require.NoError(t, flat.LoadFlat("db_", &out))
+	require.Equal(t, "flat.internal", out.Host)
+	require.Equal(t, 6543, out.Port)
+
+	// Without a trailing separator, "." and "_" both follow the prefix.
+	out = dbConfig{}
+	require.NoError(t, nested.LoadFlat("db", &out))
+	require.Equal(t, "db.internal", out.Host)
+	require.Equal(t, 10, out.Pool.Size)
+
+	out = dbConfig{}
+	require.NoError(t, flat.LoadFlat("DB", &out))
+	require.Equal(t, "flat.internal", out.Host)
+	require.Equal(t, 6543, out.Port)
+
+	unrelated := config.New(config.WithInitialValues(map[string]any{"dbname": "x"}))
+	require.ErrorIs(t, unrelated.LoadFlat("db", &dbConfig{}), configerrors.ErrKeyNotFound)
+
+	// Validation runs as in Load.
+	invalid := config.New(config.WithInitialValues(map[string]any{
+		"db": map[string]any{"port": 0},
+	}))
+	err := invalid.LoadFlat("db.", &dbConfig{})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "field 'dbConfig.Host' failed 'required'")
+
+	require.ErrorIs(t, nested.LoadFlat("queue.", &dbConfig{}), configerrors.ErrKeyNotFound)
+	require.Error(t, nested.LoadFlat("db.", nil))
+}
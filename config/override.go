@@ -0,0 +1,84 @@
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/next-trace/scg-config/configerrors"
+)
+
+// ApplyOverrides applies Helm --set style overrides such as
+// "server.port=9090" or "auth.enabled=true" and refreshes the getter. Dotted
+// keys address nested values, and each override is written via Provider.Set,
+// so it takes precedence over files and environment variables. Values are
+// typed by inference: true/false become bools, then integers, then decimal
+// floats such as 0.5 or 1e3; anything else (including nan or inf), or a value
+// wrapped in single or double quotes (e.g. version="1.10"), is kept as a
+// string. All pairs are parsed before any is applied, so a malformed pair (no
+// "=" or an empty key) returns ErrInvalidOverride naming it and leaves the
+// configuration unchanged.
+func (c *Config) ApplyOverrides(pairs []string) error {
+	if err := c.checkFrozen(); err != nil {
+		return err
+	}
+
+	keys := make([]string, len(pairs))
+	values := make([]any, len(pairs))
+
+	for i, pair := range pairs {
+		key, raw, ok := strings.Cut(pair, "=")
+		key = strings.TrimSpace(key)
+
+		if !ok || key == "" {
+			return fmt.Errorf("%w: %q", configerrors.ErrInvalidOverride, pair)
+		}
+
+		keys[i] = key
+		values[i] = inferOverrideValue(raw)
+	}
+
+	return c.update(func() error {
+		for i, key := range keys {
+			c.provider.Set(key, values[i])
+		}
+
+		return nil
+	})
+}
+
+// decimalLiteral matches the floats ApplyOverrides infers: digits with an
+// optional fraction and exponent. ParseFloat alone would also turn words such
+// as "nan", "inf" or "Infinity" and hex floats into numbers.
+//
+//nolint:gochecknoglobals // compiled once instead of on every override
+var decimalLiteral = regexp.MustCompile(`^[+-]?(\d+(\.\d*)?|\.\d+)([eE][+-]?\d+)?$`)
+
+// inferOverrideValue types a raw override value for ApplyOverrides.
+func inferOverrideValue(raw string) any {
+	if len(raw) >= 2 && (raw[0] == '"' || raw[0] == '\'') && raw[len(raw)-1] == raw[0] {
+		return raw[1 : len(raw)-1]
+	}
+
+	switch raw {
+	case "true":
+		return true
+	case "false":
+		return false
+	}
+
+	if i, err := strconv.Atoi(raw); err == nil {
+		return i
+	}
+
+	if !decimalLiteral.MatchString(raw) {
+		return raw
+	}
+
+	if f, err := strconv.ParseFloat(raw, 64); err == nil {
+		return f
+	}
+
+	return raw
+}
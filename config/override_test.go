@@ -0,0 +1,71 @@
+package config_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/next-trace/scg-config/config"
+	"github.com/next-trace/scg-config/configerrors"
+	"github.com/next-trace/scg-config/contract"
+)
+
+func TestConfig_ApplyOverrides(t *testing.T) {
+	t.Parallel()
+	cfg := config.New(config.WithInitialValues(map[string]any{
+		"server": map[string]any{"port": 8080, "host": "localhost"},
+	}))
+
+	require.NoError(t, cfg.ApplyOverrides([]string{
+		"server.port=9090",
+		"auth.enabled=true",
+		"auth.ratio=0.5",
+		`app.version="1.10"`,
+		"app.tag='42'",
+		"app.name=svc=blue",
+		"app.empty=",
+		"num.exp=1e3",
+		"num.nan=nan",
+		"num.inf=inf",
+		"num.posinf=+Inf",
+		"num.infinity=Infinity",
+		"num.hex=0x1p-2",
+	}))
+
+	cases := map[string]any{
+		"server.port":  9090,
+		"server.host":  "localhost",
+		"auth.enabled": true,
+		"auth.ratio":   0.5,
+		"app.version":  "1.10",
+		"app.tag":      "42",
+		"app.name":     "svc=blue",
+		"app.empty":    "",
+		"num.exp":      1000.0,
+		"num.nan":      "nan",
+		"num.inf":      "inf",
+		"num.posinf":   "+Inf",
+		"num.infinity": "Infinity",
+		"num.hex":      "0x1p-2",
+	}
+	for key, want := range cases {
+		got, ok := cfg.GetRawKey(key)
+		require.True(t, ok, key)
+		assert.Equal(t, want, got, key)
+	}
+
+	auth, err := cfg.Get("auth", contract.Map)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"enabled": true, "ratio": 0.5}, auth)
+
+	for _, pair := range []string{"server.port", "=1"} {
+		err := cfg.ApplyOverrides([]string{"server.host=changed", pair})
+		require.ErrorIs(t, err, configerrors.ErrInvalidOverride)
+		require.ErrorContains(t, err, pair)
+	}
+
+	host, err := cfg.Get("server.host", contract.String)
+	require.NoError(t, err)
+	assert.Equal(t, "localhost", host, "a malformed pair must not apply the others")
+}
@@ -0,0 +1,50 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/next-trace/scg-config/configerrors"
+)
+
+// ProfilesKey is the top-level key holding named configuration profiles.
+const ProfilesKey = "profiles"
+
+// UseProfile merges the subtree of the named profile found under the top-level
+// "profiles" map over the base configuration and refreshes the getter. For
+// example, with profiles: {prod: {server: {port: 443}}}, UseProfile("prod")
+// makes server.port resolve to 443. Unknown names return ErrUnknownProfile
+// listing the available profiles.
+func (c *Config) UseProfile(name string) error {
+	if err := c.checkFrozen(); err != nil {
+		return err
+	}
+
+	return c.update(func() error {
+		profiles, _ := c.provider.AllSettings()[ProfilesKey].(map[string]any)
+
+		profile, ok := profiles[strings.ToLower(name)]
+		if !ok {
+			available := make([]string, 0, len(profiles))
+			for profileName := range profiles {
+				available = append(available, profileName)
+			}
+
+			sort.Strings(available)
+
+			return fmt.Errorf("%w: %q (available: %s)", configerrors.ErrUnknownProfile, name, strings.Join(available, ", "))
+		}
+
+		profileMap, ok := profile.(map[string]any)
+		if !ok {
+			return fmt.Errorf("%w: profile %q", configerrors.ErrNotMap, name)
+		}
+
+		if err := c.provider.MergeConfigMap(profileMap); err != nil {
+			return fmt.Errorf("error applying profile %q: %w", name, err)
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,84 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/next-trace/scg-config/config"
+	"github.com/next-trace/scg-config/configerrors"
+	"github.com/next-trace/scg-config/contract"
+)
+
+const profileYAML = `
+server:
+  host: localhost
+  port: 8080
+profiles:
+  dev:
+    server:
+      port: 3000
+  prod:
+    server:
+      host: example.com
+      port: 443
+`
+
+func TestConfig_UseProfile(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "app.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(profileYAML), 0o600))
+
+	cfg := config.New()
+	require.NoError(t, cfg.FileLoader().LoadFromFile(path))
+	require.NoError(t, cfg.Reload())
+
+	require.NoError(t, cfg.UseProfile("prod"))
+
+	host, err := cfg.Get("server.host", contract.String)
+	require.NoError(t, err)
+	require.Equal(t, "example.com", host)
+
+	port, err := cfg.Get("server.port", contract.Int)
+	require.NoError(t, err)
+	require.Equal(t, 443, port)
+}
+
+func TestConfig_UseProfile_KeepsBaseValues(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "app.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(profileYAML), 0o600))
+
+	cfg := config.New()
+	require.NoError(t, cfg.FileLoader().LoadFromFile(path))
+	require.NoError(t, cfg.UseProfile("dev"))
+
+	host, err := cfg.Get("server.host", contract.String)
+	require.NoError(t, err)
+	require.Equal(t, "localhost", host)
+
+	port, err := cfg.Get("server.port", contract.Int)
+	require.NoError(t, err)
+	require.Equal(t, 3000, port)
+}
+
+func TestConfig_UseProfile_Unknown(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "app.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(profileYAML), 0o600))
+
+	cfg := config.New()
+	require.NoError(t, cfg.FileLoader().LoadFromFile(path))
+
+	err := cfg.UseProfile("staging")
+	require.ErrorIs(t, err, configerrors.ErrUnknownProfile)
+	require.Contains(t, err.Error(), "available: dev, prod")
+}
+
+func TestConfig_UseProfile_NoProfiles(t *testing.T) {
+	t.Parallel()
+	cfg := config.New(config.WithProvider(&fakeProvider{all: map[string]any{"a": 1}}))
+	require.ErrorIs(t, cfg.UseProfile("dev"), configerrors.ErrUnknownProfile)
+}
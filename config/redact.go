@@ -0,0 +1,107 @@
+package config
+
+import (
+	"reflect"
+	"strconv"
+)
+
+// RedactedValue replaces the content of sensitive string fields in Redact.
+const RedactedValue = "****"
+
+// sensitiveTag is the struct tag that marks a field for redaction.
+const sensitiveTag = "sensitive"
+
+// Redact returns a copy of v, typically a struct decoded by Load, that is safe
+// to log: non-empty string fields tagged `sensitive:"true"` are replaced by
+// RedactedValue, and sensitive fields of other types are reset to their zero
+// value. Nested structs, pointers, slices and maps are walked and copied, so v
+// itself is never modified; untagged fields keep their values. The result has
+// the same type as v. Unexported fields are copied as is.
+func Redact(v any) any {
+	if v == nil {
+		return nil
+	}
+
+	return redactValue(reflect.ValueOf(v)).Interface()
+}
+
+// redactValue returns a redacted copy of value.
+func redactValue(value reflect.Value) reflect.Value {
+	switch value.Kind() {
+	case reflect.Pointer:
+		if value.IsNil() {
+			return value
+		}
+
+		result := reflect.New(value.Type().Elem())
+		result.Elem().Set(redactValue(value.Elem()))
+
+		return result
+	case reflect.Struct:
+		return redactStruct(value)
+	case reflect.Slice:
+		if value.IsNil() {
+			return value
+		}
+
+		result := reflect.MakeSlice(value.Type(), value.Len(), value.Len())
+		for i := range value.Len() {
+			result.Index(i).Set(redactValue(value.Index(i)))
+		}
+
+		return result
+	case reflect.Map:
+		if value.IsNil() {
+			return value
+		}
+
+		result := reflect.MakeMapWithSize(value.Type(), value.Len())
+		for iter := value.MapRange(); iter.Next(); {
+			result.SetMapIndex(iter.Key(), redactValue(iter.Value()))
+		}
+
+		return result
+	default:
+		return value
+	}
+}
+
+// redactStruct copies value and redacts its exported fields.
+func redactStruct(value reflect.Value) reflect.Value {
+	result := reflect.New(value.Type()).Elem()
+	result.Set(value)
+
+	for i := range value.NumField() {
+		field := value.Type().Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		if sensitive, _ := strconv.ParseBool(field.Tag.Get(sensitiveTag)); sensitive {
+			result.Field(i).Set(maskValue(value.Field(i)))
+
+			continue
+		}
+
+		result.Field(i).Set(redactValue(value.Field(i)))
+	}
+
+	return result
+}
+
+// maskValue returns the redacted form of a sensitive field value.
+func maskValue(value reflect.Value) reflect.Value {
+	switch {
+	case value.Kind() == reflect.String && value.Len() > 0:
+		return reflect.ValueOf(RedactedValue).Convert(value.Type())
+	case value.Kind() == reflect.Pointer && !value.IsNil() && value.Elem().Kind() == reflect.String:
+		result := reflect.New(value.Type().Elem())
+		result.Elem().Set(maskValue(value.Elem()))
+
+		return result
+	case value.Kind() == reflect.String:
+		return value
+	default:
+		return reflect.Zero(value.Type())
+	}
+}
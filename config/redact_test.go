@@ -0,0 +1,69 @@
+package config_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/next-trace/scg-config/config"
+)
+
+type redactDB struct {
+	Host     string `mapstructure:"host"`
+	Password string `mapstructure:"password" sensitive:"true"`
+}
+
+type redactConfig struct {
+	Name    string              `mapstructure:"name"`
+	APIKey  string              `mapstructure:"api_key"  sensitive:"true"`
+	Empty   string              `mapstructure:"empty"    sensitive:"true"`
+	Token   *string             `mapstructure:"token"    sensitive:"true"`
+	Port    int                 `mapstructure:"port"     sensitive:"true"`
+	DB      redactDB            `mapstructure:"db"`
+	Replica *redactDB           `mapstructure:"replica"`
+	Shards  []redactDB          `mapstructure:"shards"`
+	Named   map[string]redactDB `mapstructure:"named"`
+	Missing *redactDB           `mapstructure:"missing"`
+}
+
+func TestRedact(t *testing.T) {
+	t.Parallel()
+	token := "tok"
+	in := &redactConfig{
+		Name:    "svc",
+		APIKey:  "key",
+		Token:   &token,
+		Port:    5432,
+		DB:      redactDB{Host: "db", Password: "pw"},
+		Replica: &redactDB{Host: "replica", Password: "pw2"},
+		Shards:  []redactDB{{Host: "s1", Password: "pw3"}},
+		Named:   map[string]redactDB{"eu": {Host: "eu", Password: "pw4"}},
+	}
+
+	out, ok := config.Redact(in).(*redactConfig)
+	require.True(t, ok)
+
+	assert.Equal(t, "svc", out.Name)
+	assert.Equal(t, config.RedactedValue, out.APIKey)
+	assert.Empty(t, out.Empty)
+	assert.Equal(t, config.RedactedValue, *out.Token)
+	assert.Zero(t, out.Port)
+	assert.Equal(t, redactDB{Host: "db", Password: config.RedactedValue}, out.DB)
+	assert.Equal(t, redactDB{Host: "replica", Password: config.RedactedValue}, *out.Replica)
+	assert.Equal(t, []redactDB{{Host: "s1", Password: config.RedactedValue}}, out.Shards)
+	assert.Equal(t, redactDB{Host: "eu", Password: config.RedactedValue}, out.Named["eu"])
+	assert.Nil(t, out.Missing)
+
+	// The original is left untouched.
+	assert.Equal(t, "key", in.APIKey)
+	assert.Equal(t, "tok", token)
+	assert.Equal(t, "pw2", in.Replica.Password)
+	assert.Equal(t, "pw3", in.Shards[0].Password)
+	assert.Equal(t, "pw4", in.Named["eu"].Password)
+
+	byValue, ok := config.Redact(in.DB).(redactDB)
+	require.True(t, ok)
+	assert.Equal(t, config.RedactedValue, byValue.Password)
+	assert.Nil(t, config.Redact(nil))
+}
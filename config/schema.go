@@ -0,0 +1,115 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/next-trace/scg-config/configerrors"
+	"github.com/next-trace/scg-config/contract"
+)
+
+// DeclareType records the KeyType of key so it can be read with GetTyped
+// without repeating the type. Declaring a key again replaces its type.
+func (c *Config) DeclareType(key string, typ contract.KeyType) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.types[key] = typ
+}
+
+// GetTyped returns the value of key converted to the type declared for it via
+// DeclareType. Keys without a declaration return ErrUnknownType.
+func (c *Config) GetTyped(key string) (any, error) {
+	c.mu.RLock()
+	typ, ok := c.types[key]
+	c.mu.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("%w: no type declared for %q", configerrors.ErrUnknownType, key)
+	}
+
+	return c.Get(key, typ)
+}
+
+// CheckAllowedTopLevel returns the top-level keys of the current snapshot that
+// are not in allowed, sorted. Keys are compared case-insensitively. It is a
+// lightweight guard against typos such as "databse" in a config file.
+func (c *Config) CheckAllowedTopLevel(allowed []string) []string {
+	permitted := make(map[string]bool, len(allowed))
+	for _, key := range allowed {
+		permitted[strings.ToLower(key)] = true
+	}
+
+	var unexpected []string
+
+	for key := range c.snapshot().config {
+		if !permitted[strings.ToLower(key)] {
+			unexpected = append(unexpected, key)
+		}
+	}
+
+	sort.Strings(unexpected)
+
+	return unexpected
+}
+
+// KeySpec declares one key expected by Verify.
+type KeySpec struct {
+	// Key is the dotted key, e.g. "server.port".
+	Key string
+	// Type is the KeyType the value must convert to; empty skips the check.
+	Type contract.KeyType
+	// Required makes a missing key a violation; optional keys are only
+	// checked when present.
+	Required bool
+	// Validate, when set, is called with the converted value and reports a
+	// violation by returning an error, e.g. for range or format checks.
+	Validate func(value any) error
+}
+
+// Schema lists the keys a configuration is expected to provide.
+type Schema []KeySpec
+
+// Verify checks the current snapshot against schema and reports every
+// violation at once, making it a single startup gate for getter-based code:
+// missing required keys (ErrKeyNotFound), values that do not convert to the
+// declared type (ErrWrongType) and values rejected by Validate. The returned
+// error joins one error per violation, in schema order, each naming its key;
+// errors.Is works on every one of them. It returns nil when all keys conform.
+func (c *Config) Verify(schema Schema) error {
+	getter := c.snapshot()
+
+	var problems []error
+
+	for _, spec := range schema {
+		if !getter.HasKey(spec.Key) {
+			if spec.Required {
+				problems = append(problems, fmt.Errorf("config: key %q: %w", spec.Key, configerrors.ErrKeyNotFound))
+			}
+
+			continue
+		}
+
+		value, _ := getter.lookup(spec.Key)
+		if spec.Type != "" {
+			converted, err := getter.Get(spec.Key, spec.Type)
+			if err != nil {
+				problems = append(problems, fmt.Errorf("config: key %q: %w", spec.Key, err))
+
+				continue
+			}
+
+			value = converted
+		}
+
+		if spec.Validate != nil {
+			if err := spec.Validate(value); err != nil {
+				problems = append(problems, fmt.Errorf("config: key %q: %w", spec.Key, err))
+			}
+		}
+	}
+
+	return errors.Join(problems...)
+}
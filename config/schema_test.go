@@ -0,0 +1,107 @@
+package config_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/next-trace/scg-config/config"
+	"github.com/next-trace/scg-config/configerrors"
+	"github.com/next-trace/scg-config/contract"
+)
+
+func TestConfig_DeclareType_GetTyped(t *testing.T) {
+	t.Parallel()
+	prov := &fakeProvider{all: map[string]any{
+		"server": map[string]any{"port": "8080", "debug": "true"},
+	}}
+	cfg := config.New(config.WithProvider(prov))
+
+	cfg.DeclareType("server.port", contract.Int)
+	cfg.DeclareType("server.debug", contract.Bool)
+
+	port, err := cfg.GetTyped("server.port")
+	require.NoError(t, err)
+	require.Equal(t, 8080, port)
+
+	debug, err := cfg.GetTyped("server.debug")
+	require.NoError(t, err)
+	require.Equal(t, true, debug)
+
+	_, err = cfg.GetTyped("server.host")
+	require.ErrorIs(t, err, configerrors.ErrUnknownType)
+
+	// Redeclaring replaces the type.
+	cfg.DeclareType("server.port", contract.String)
+	port, err = cfg.GetTyped("server.port")
+	require.NoError(t, err)
+	require.Equal(t, "8080", port)
+
+	// A declared but missing key reports the usual lookup error.
+	cfg.DeclareType("server.host", contract.String)
+	_, err = cfg.GetTyped("server.host")
+	require.ErrorIs(t, err, configerrors.ErrKeyNotFound)
+}
+
+func TestConfig_CheckAllowedTopLevel(t *testing.T) {
+	t.Parallel()
+	cfg := config.New(config.WithInitialValues(map[string]any{
+		"app":     map[string]any{"name": "x"},
+		"server":  map[string]any{"port": 1},
+		"databse": map[string]any{"host": "typo"},
+		"extra":   true,
+	}))
+
+	require.Equal(t, []string{"databse", "extra"},
+		cfg.CheckAllowedTopLevel([]string{"app", "Server", "auth", "database"}))
+	require.Empty(t, cfg.CheckAllowedTopLevel([]string{"app", "server", "databse", "extra"}))
+}
+
+func TestConfig_Verify(t *testing.T) {
+	t.Parallel()
+	prov := &fakeProvider{all: map[string]any{
+		"server": map[string]any{"port": "70000", "host": "localhost", "timeout": "soon"},
+		"debug":  "true",
+	}}
+	cfg := config.New(config.WithProvider(prov))
+
+	validPort := func(value any) error {
+		port, _ := value.(int)
+		if port < 1 || port > 65535 {
+			return fmt.Errorf("%w: port %d", configerrors.ErrOutOfRange, port)
+		}
+
+		return nil
+	}
+
+	err := cfg.Verify(config.Schema{
+		{Key: "server.host", Type: contract.String, Required: true},
+		{Key: "server.port", Type: contract.Int, Required: true, Validate: validPort},
+		{Key: "server.timeout", Type: contract.Duration},
+		{Key: "database.url", Type: contract.URL, Required: true},
+		{Key: "cache.ttl", Type: contract.Duration},
+		{Key: "debug", Type: contract.Bool},
+	})
+	require.Error(t, err)
+	require.ErrorIs(t, err, configerrors.ErrOutOfRange)
+	require.ErrorIs(t, err, configerrors.ErrWrongType)
+	require.ErrorIs(t, err, configerrors.ErrKeyNotFound)
+
+	lines := strings.Split(err.Error(), "\n")
+	require.Len(t, lines, 3)
+	require.Contains(t, lines[0], `"server.port"`)
+	require.Contains(t, lines[1], `"server.timeout"`)
+	require.Contains(t, lines[2], `"database.url"`)
+
+	require.NoError(t, cfg.Verify(config.Schema{
+		{Key: "server.host", Type: contract.String, Required: true},
+		{Key: "debug", Type: contract.Bool, Required: true},
+		{Key: "server", Required: true},
+	}))
+	// Top-level keys report wrong types like nested ones.
+	err = cfg.Verify(config.Schema{{Key: "debug", Type: contract.Int}})
+	require.ErrorIs(t, err, configerrors.ErrWrongType)
+	require.Contains(t, err.Error(), `"debug"`)
+}
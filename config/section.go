@@ -0,0 +1,102 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// sectionKey identifies a cached decoded section by config key and target type.
+type sectionKey struct {
+	key string
+	typ reflect.Type
+}
+
+// Section decodes the sub-tree at key into a value of type T using the same
+// decoding and validation rules as LoadKey. Decoded values are cached per
+// (key, type) pair so hot paths do not re-decode; the cache is cleared on
+// Reload so stale values are never served after a configuration change.
+//
+// Reference types within T (maps, slices, pointers) are shared between callers
+// of the cached value and the configuration snapshot, and must not be mutated.
+func Section[T any](c *Config, key string) (T, error) {
+	cacheKey := sectionKey{key: key, typ: reflect.TypeFor[T]()}
+
+	c.mu.RLock()
+	getter := c.getter
+	cached, ok := c.sections[cacheKey]
+	c.mu.RUnlock()
+
+	if ok {
+		if value, isT := cached.(T); isT {
+			return value, nil
+		}
+	}
+
+	// Decode from the snapshot the cache belongs to, not the live provider.
+	var out T
+	if err := decodeKey(c.withEnvOverlay(getter.config), key, &out); err != nil {
+		var zero T
+
+		return zero, err
+	}
+
+	c.mu.Lock()
+	// A refresh since the lookup swapped the getter and cleared the cache;
+	// out was decoded from the old snapshot and must not be cached.
+	if c.getter == getter {
+		c.sections[cacheKey] = out
+	}
+	c.mu.Unlock()
+
+	return out, nil
+}
+
+// binding is a target registered via Bind.
+type binding struct {
+	key string
+	out reflect.Value
+}
+
+// Bind decodes the sub-tree at key into out, a non-nil pointer, and keeps it
+// in sync: every later refresh of the configuration (Reload, a watched file
+// change, SetMap, ...) re-decodes key into out with the same decoding and
+// validation rules as LoadKey. A re-decode that fails, e.g. because the new
+// values do not validate, leaves out unchanged and is reported like a failed
+// watcher reload (WithReloadErrorHandler, LastReloadError). out is written on
+// the reloading goroutine, so concurrent readers need their own
+// synchronization.
+func (c *Config) Bind(key string, out any) error {
+	target := reflect.ValueOf(out)
+	if out == nil || target.Kind() != reflect.Pointer || target.IsNil() {
+		return fmt.Errorf("config: bind target for %q must be a non-nil pointer", key)
+	}
+
+	c.bindMu.Lock()
+	defer c.bindMu.Unlock()
+
+	if err := c.LoadKey(key, out); err != nil {
+		return err
+	}
+
+	c.bindings = append(c.bindings, binding{key: key, out: target})
+
+	return nil
+}
+
+// rebind re-decodes every bound target after a refresh. Each target is
+// decoded into a fresh value first, so a failure leaves it untouched.
+func (c *Config) rebind() {
+	c.bindMu.Lock()
+	defer c.bindMu.Unlock()
+
+	for _, bound := range c.bindings {
+		fresh := reflect.New(bound.out.Type().Elem())
+		if err := c.LoadKey(bound.key, fresh.Interface()); err != nil {
+			c.reportReloadError(fmt.Errorf("config: re-binding %q, keeping previous value: %w", bound.key, err))
+
+			continue
+		}
+
+		bound.out.Elem().Set(fresh.Elem())
+	}
+}
@@ -0,0 +1,171 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/next-trace/scg-config/config"
+	"github.com/next-trace/scg-config/configerrors"
+)
+
+type databaseConfig struct {
+	Host string `mapstructure:"host" validate:"required"`
+	Port int    `mapstructure:"port" validate:"min=1,max=65535"`
+}
+
+func TestConfig_LoadKey(t *testing.T) {
+	t.Parallel()
+	prov := &fakeProvider{all: map[string]any{
+		"database": map[string]any{"host": "db", "port": "5432"},
+		"invalid":  map[string]any{"port": 0},
+	}}
+	cfg := config.New(config.WithProvider(prov))
+
+	var db databaseConfig
+	require.NoError(t, cfg.LoadKey("database", &db))
+	require.Equal(t, databaseConfig{Host: "db", Port: 5432}, db)
+
+	require.ErrorIs(t, cfg.LoadKey("missing", &db), configerrors.ErrKeyNotFound)
+	require.Error(t, cfg.LoadKey("invalid", &db))
+	require.Error(t, cfg.LoadKey("database", nil))
+}
+
+func TestSection_CachesUntilReload(t *testing.T) {
+	t.Parallel()
+	prov := &fakeProvider{all: map[string]any{
+		"database": map[string]any{"host": "db1", "port": 5432},
+	}}
+	cfg := config.New(config.WithProvider(prov))
+
+	db, err := config.Section[databaseConfig](cfg, "database")
+	require.NoError(t, err)
+	require.Equal(t, "db1", db.Host)
+
+	// Without a reload the cached value is served.
+	prov.all = map[string]any{"database": map[string]any{"host": "db2", "port": 5432}}
+	db, err = config.Section[databaseConfig](cfg, "database")
+	require.NoError(t, err)
+	require.Equal(t, "db1", db.Host)
+
+	// Reload invalidates the cache.
+	require.NoError(t, cfg.Reload())
+	db, err = config.Section[databaseConfig](cfg, "database")
+	require.NoError(t, err)
+	require.Equal(t, "db2", db.Host)
+
+	// The same key decoded into another type is cached separately.
+	raw, err := config.Section[map[string]any](cfg, "database")
+	require.NoError(t, err)
+	require.Equal(t, "db2", raw["host"])
+}
+
+func TestSection_NeverCachesStaleValueAcrossRefresh(t *testing.T) {
+	t.Parallel()
+	cfg := config.New(config.WithInitialValues(map[string]any{
+		"database": map[string]any{"host": "db", "port": 1},
+	}))
+
+	const refreshes = 200
+
+	var wg sync.WaitGroup
+
+	wg.Add(1)
+
+	go func() {
+		defer wg.Done()
+
+		for port := 2; port <= refreshes; port++ {
+			_ = cfg.SetMap(map[string]any{"database": map[string]any{"port": port}})
+		}
+	}()
+
+	for range refreshes {
+		_, err := config.Section[databaseConfig](cfg, "database")
+		require.NoError(t, err)
+	}
+
+	wg.Wait()
+
+	// Whatever the interleaving, the cache reflects the last refresh.
+	db, err := config.Section[databaseConfig](cfg, "database")
+	require.NoError(t, err)
+	require.Equal(t, refreshes, db.Port)
+}
+
+func TestSection_ValidationError(t *testing.T) {
+	t.Parallel()
+	prov := &fakeProvider{all: map[string]any{"database": map[string]any{"port": 0}}}
+	cfg := config.New(config.WithProvider(prov))
+
+	_, err := config.Section[databaseConfig](cfg, "database")
+	require.Error(t, err)
+}
+
+func TestConfig_LoadKey_SliceOfStructs(t *testing.T) {
+	t.Parallel()
+
+	type serverConfig struct {
+		Host string `mapstructure:"host" validate:"required"`
+		Port int    `mapstructure:"port" validate:"min=1,max=65535"`
+	}
+
+	prov := &fakeProvider{all: map[string]any{
+		"servers": []any{
+			map[string]any{"host": "a", "port": 80},
+			map[string]any{"host": "b", "port": "443"},
+		},
+		"broken": []any{
+			map[string]any{"host": "a", "port": 80},
+			map[string]any{"host": "b", "port": 0},
+		},
+	}}
+	cfg := config.New(config.WithProvider(prov))
+
+	var servers []serverConfig
+	require.NoError(t, cfg.LoadKey("servers", &servers))
+	require.Equal(t, []serverConfig{{Host: "a", Port: 80}, {Host: "b", Port: 443}}, servers)
+
+	var broken []serverConfig
+	err := cfg.LoadKey("broken", &broken)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "field 'broken[1].port' failed 'min'='1'")
+	require.NotContains(t, err.Error(), "broken[0]")
+}
+
+func TestConfig_Bind(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("database:\n  host: db.one\n  port: 5432\n"), 0o600))
+
+	var reloadErrs []error
+
+	cfg := config.New(config.WithReloadErrorHandler(func(err error) { reloadErrs = append(reloadErrs, err) }))
+	require.NoError(t, cfg.FileLoader().LoadFromFile(path))
+	require.NoError(t, cfg.Reload())
+
+	var db databaseConfig
+	require.NoError(t, cfg.Bind("database", &db))
+	require.Equal(t, databaseConfig{Host: "db.one", Port: 5432}, db)
+
+	require.NoError(t, os.WriteFile(path, []byte("database:\n  host: db.two\n  port: 6543\n"), 0o600))
+	require.NoError(t, cfg.Reload())
+	require.Equal(t, databaseConfig{Host: "db.two", Port: 6543}, db)
+
+	// Invalid values are validated on re-decode and leave the target as is.
+	require.NoError(t, os.WriteFile(path, []byte("database:\n  host: db.three\n  port: 0\n"), 0o600))
+	require.NoError(t, cfg.Reload())
+	require.Equal(t, databaseConfig{Host: "db.two", Port: 6543}, db)
+	require.Len(t, reloadErrs, 1)
+	require.Contains(t, reloadErrs[0].Error(), "database")
+
+	// The binding survives the failed re-decode.
+	require.NoError(t, cfg.SetMap(map[string]any{"database": map[string]any{"port": 7000}}))
+	require.Equal(t, databaseConfig{Host: "db.three", Port: 7000}, db)
+
+	require.Error(t, cfg.Bind("database", databaseConfig{}))
+	require.ErrorIs(t, cfg.Bind("missing", &databaseConfig{}), configerrors.ErrKeyNotFound)
+}
@@ -0,0 +1,95 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"sync"
+
+	"github.com/next-trace/scg-config/configerrors"
+	"github.com/next-trace/scg-config/contract"
+)
+
+// ReloadOnSignal reloads the configuration every time the process receives
+// sig (typically syscall.SIGHUP), giving env-first deployments without config
+// files a reload trigger similar to StartWatching. Each reload re-reads the
+// provider's sources like Reload and, when the env loader implements
+// contract.EnvReloader (as env.Loader does), loads the environment again for
+// the prefixes passed to LoadFromEnv; the getter is then refreshed. Errors go
+// to the WithReloadErrorHandler handler and LastReloadError. The returned stop
+// function removes the handler; Close removes it as well. stop may be called
+// more than once.
+func (c *Config) ReloadOnSignal(sig os.Signal) (stop func()) {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, sig)
+
+	quit := make(chan struct{})
+
+	var once sync.Once
+
+	stop = func() {
+		once.Do(func() {
+			signal.Stop(signals)
+			close(quit)
+		})
+	}
+
+	go func() {
+		for {
+			select {
+			case <-signals:
+				c.triggeredReload(c.reloadWithEnv)
+			case <-quit:
+				return
+			case <-c.done:
+				stop()
+
+				return
+			}
+		}
+	}()
+
+	return stop
+}
+
+// reloadWithEnv is Reload followed by reloading the environment variables,
+// in one update so readers never see the file values without the env ones.
+func (c *Config) reloadWithEnv() error {
+	if err := c.checkFrozen(); err != nil {
+		return err
+	}
+
+	return c.update(func() error {
+		if err := c.provider.ReadInConfig(); err != nil {
+			return fmt.Errorf("error reloading config: %w", err)
+		}
+
+		if reloader, ok := c.envLoader.(contract.EnvReloader); ok {
+			if err := reloader.ReloadFromEnv(); err != nil {
+				return fmt.Errorf("error reloading environment: %w", err)
+			}
+		}
+
+		return nil
+	})
+}
+
+// triggeredReload runs reload for a signal or a stream push, reporting errors
+// like a watcher-triggered reload. Once the Config is frozen triggers are
+// ignored, like pending watcher reloads, rather than reported.
+func (c *Config) triggeredReload(reload func() error) {
+	if err := reload(); err != nil {
+		if errors.Is(err, configerrors.ErrFrozen) {
+			return
+		}
+
+		c.reportReloadError(err)
+
+		return
+	}
+
+	c.mu.Lock()
+	c.reloadErr = nil
+	c.mu.Unlock()
+}
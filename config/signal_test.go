@@ -0,0 +1,83 @@
+//go:build unix
+
+package config_test
+
+import (
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/next-trace/scg-config/config"
+	"github.com/next-trace/scg-config/contract"
+	"github.com/next-trace/scg-config/provider/viper"
+)
+
+func TestConfig_ReloadOnSignal(t *testing.T) {
+	t.Parallel()
+	prov := &countingProvider{ConfigProvider: viper.NewConfigProvider()}
+	cfg := config.New(config.WithProvider(prov))
+
+	stop := cfg.ReloadOnSignal(syscall.SIGHUP)
+	defer stop()
+
+	base := prov.reads.Load()
+	require.NoError(t, syscall.Kill(syscall.Getpid(), syscall.SIGHUP))
+	require.Eventually(t, func() bool { return prov.reads.Load() == base+1 }, 2*time.Second, 10*time.Millisecond)
+
+	require.NoError(t, syscall.Kill(syscall.Getpid(), syscall.SIGHUP))
+	require.Eventually(t, func() bool { return prov.reads.Load() == base+2 }, 2*time.Second, 10*time.Millisecond)
+
+	stop()
+	stop()
+}
+
+func TestConfig_ReloadOnSignal_ReloadsEnv(t *testing.T) {
+	// Note: Cannot use t.Parallel() with t.Setenv()
+	t.Setenv("SIGRELOAD_APP_NAME", "before")
+
+	cfg := config.New()
+	require.NoError(t, cfg.EnvLoader().LoadFromEnv("SIGRELOAD"))
+	require.NoError(t, cfg.Reload())
+
+	name, err := cfg.Get("app.name", contract.String)
+	require.NoError(t, err)
+	require.Equal(t, "before", name)
+
+	stop := cfg.ReloadOnSignal(syscall.SIGHUP)
+	defer stop()
+
+	t.Setenv("SIGRELOAD_APP_NAME", "after")
+	require.NoError(t, syscall.Kill(syscall.Getpid(), syscall.SIGHUP))
+	require.Eventually(t, func() bool {
+		name, err := cfg.Get("app.name", contract.String)
+
+		return err == nil && name == "after"
+	}, 2*time.Second, 10*time.Millisecond)
+	require.NoError(t, cfg.LastReloadError())
+}
+
+func TestConfig_ReloadOnSignal_IgnoredOnceFrozen(t *testing.T) {
+	t.Parallel()
+	prov := &countingProvider{ConfigProvider: viper.NewConfigProvider()}
+	cfg := config.New(config.WithProvider(prov))
+
+	stop := cfg.ReloadOnSignal(syscall.SIGUSR1)
+	defer stop()
+
+	require.NoError(t, cfg.Freeze())
+
+	base := prov.reads.Load()
+	require.NoError(t, syscall.Kill(syscall.Getpid(), syscall.SIGUSR1))
+	require.Never(t, func() bool { return cfg.LastReloadError() != nil }, 300*time.Millisecond, 10*time.Millisecond)
+	require.Equal(t, base, prov.reads.Load())
+}
+
+func TestConfig_ReloadOnSignal_StopsOnClose(t *testing.T) {
+	t.Parallel()
+	cfg := config.New()
+	stop := cfg.ReloadOnSignal(syscall.SIGUSR2)
+	require.NoError(t, cfg.Close())
+	stop()
+}
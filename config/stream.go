@@ -0,0 +1,43 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/next-trace/scg-config/contract"
+)
+
+// StartStreaming reloads the configuration every time the provider pushes a
+// new snapshot, for providers implementing contract.StreamProvider such as
+// stream.Provider. It performs an initial Reload, which opens the stream and
+// waits for the first snapshot, then reloads on every push until the stream
+// ends or the Config is closed; Close also closes the stream. Reload errors go
+// to the WithReloadErrorHandler handler and LastReloadError.
+func (c *Config) StartStreaming() error {
+	streamer, ok := c.provider.(contract.StreamProvider)
+	if !ok {
+		return fmt.Errorf("config: provider %T does not support streaming", c.provider)
+	}
+
+	if err := c.Reload(); err != nil {
+		return err
+	}
+
+	go func() {
+		updates := streamer.Updates()
+
+		for {
+			select {
+			case _, ok := <-updates:
+				if !ok {
+					return
+				}
+
+				c.triggeredReload(c.Reload)
+			case <-c.done:
+				return
+			}
+		}
+	}()
+
+	return nil
+}
@@ -0,0 +1,70 @@
+package config_test
+
+import (
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/next-trace/scg-config/config"
+	"github.com/next-trace/scg-config/contract"
+	"github.com/next-trace/scg-config/provider/stream"
+)
+
+// pushSource is a stream.Source fed by a channel, standing in for a gRPC stream.
+type pushSource struct {
+	snapshots chan map[string]any
+	closed    chan struct{}
+	once      sync.Once
+}
+
+func (s *pushSource) Recv() (map[string]any, error) {
+	select {
+	case snapshot := <-s.snapshots:
+		return snapshot, nil
+	case <-s.closed:
+		return nil, io.EOF
+	}
+}
+
+func (s *pushSource) Close() error {
+	s.once.Do(func() { close(s.closed) })
+
+	return nil
+}
+
+func TestConfig_StartStreaming_ReloadsOnPush(t *testing.T) {
+	t.Parallel()
+	source := &pushSource{snapshots: make(chan map[string]any, 1), closed: make(chan struct{})}
+	cfg := config.New(config.WithProvider(stream.NewProvider(source)))
+
+	source.snapshots <- map[string]any{"feature": map[string]any{"enabled": false}}
+	require.NoError(t, cfg.StartStreaming())
+
+	enabled, err := cfg.Get("feature.enabled", contract.Bool)
+	require.NoError(t, err)
+	require.Equal(t, false, enabled)
+
+	source.snapshots <- map[string]any{"feature": map[string]any{"enabled": true}}
+	require.Eventually(t, func() bool {
+		enabled, err := cfg.Get("feature.enabled", contract.Bool)
+
+		return err == nil && enabled == true
+	}, time.Second, 5*time.Millisecond)
+
+	require.NoError(t, cfg.Close())
+
+	select {
+	case <-source.closed:
+	default:
+		t.Fatal("Close did not close the stream")
+	}
+}
+
+func TestConfig_StartStreaming_UnsupportedProvider(t *testing.T) {
+	t.Parallel()
+	cfg := config.New()
+	require.Error(t, cfg.StartStreaming())
+}
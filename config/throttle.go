@@ -0,0 +1,99 @@
+package config
+
+import (
+	"sync"
+	"time"
+)
+
+// reloadThrottle limits watcher-triggered reloads to at most one per interval.
+// Triggers arriving inside the interval are coalesced into a single trailing
+// reload, so the last change is always applied.
+type reloadThrottle struct {
+	mu       sync.Mutex
+	run      sync.Mutex
+	interval time.Duration
+	last     time.Time
+	timer    *time.Timer
+	pending  func()
+}
+
+// SetReloadThrottle caps watcher-triggered reloads to at most one per
+// interval. Unlike a debounce, which waits for changes to settle, a throttle
+// keeps reloading at a steady cadence under continuous change: the first
+// trigger reloads immediately, further triggers within the interval are
+// coalesced, and one trailing reload runs when the interval ends so the last
+// change is always reflected. A zero or negative interval disables throttling.
+func (c *Config) SetReloadThrottle(interval time.Duration) {
+	c.throttle.mu.Lock()
+	defer c.throttle.mu.Unlock()
+
+	c.throttle.interval = interval
+}
+
+// do runs fn now when the interval has elapsed since the last reload, or
+// schedules it as the trailing reload otherwise, replacing any fn already
+// waiting.
+func (t *reloadThrottle) do(fn func()) {
+	t.mu.Lock()
+
+	if t.interval <= 0 {
+		t.mu.Unlock()
+		t.exec(fn)
+
+		return
+	}
+
+	if t.timer != nil {
+		t.pending = fn
+		t.mu.Unlock()
+
+		return
+	}
+
+	wait := t.interval - time.Since(t.last)
+	if wait <= 0 {
+		t.last = time.Now()
+		t.mu.Unlock()
+		t.exec(fn)
+
+		return
+	}
+
+	t.pending = fn
+	t.timer = time.AfterFunc(wait, t.fire)
+	t.mu.Unlock()
+}
+
+// fire runs the trailing reload scheduled by do.
+func (t *reloadThrottle) fire() {
+	t.mu.Lock()
+	fn := t.pending
+	t.pending = nil
+	t.timer = nil
+	t.last = time.Now()
+	t.mu.Unlock()
+
+	if fn != nil {
+		t.exec(fn)
+	}
+}
+
+// exec runs fn, never concurrently with another reload.
+func (t *reloadThrottle) exec(fn func()) {
+	t.run.Lock()
+	defer t.run.Unlock()
+
+	fn()
+}
+
+// stop cancels a scheduled trailing reload.
+func (t *reloadThrottle) stop() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.timer != nil {
+		t.timer.Stop()
+		t.timer = nil
+		t.pending = nil
+	}
+}
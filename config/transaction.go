@@ -0,0 +1,122 @@
+package config
+
+import (
+	"maps"
+	"strings"
+)
+
+// Tx buffers configuration changes made inside Config.Transaction. Nothing is
+// applied until the transaction function returns successfully.
+type Tx struct {
+	ops []func(settings map[string]any) map[string]any
+}
+
+// Set stores value at the dotted key, creating intermediate maps as needed.
+func (tx *Tx) Set(key string, value any) {
+	path := splitKey(key)
+	tx.ops = append(tx.ops, func(settings map[string]any) map[string]any {
+		return setPath(settings, path, value)
+	})
+}
+
+// Merge deep-merges m into the configuration with the same semantics as
+// Config.SetMap: nested maps are merged key by key, other values replace
+// existing ones.
+func (tx *Tx) Merge(m map[string]any) {
+	tx.ops = append(tx.ops, func(settings map[string]any) map[string]any {
+		return mergeSettings(settings, m)
+	})
+}
+
+// Unset removes the dotted key, and everything below it, from the
+// configuration. Unsetting a missing key has no effect.
+func (tx *Tx) Unset(key string) {
+	path := splitKey(key)
+	tx.ops = append(tx.ops, func(settings map[string]any) map[string]any {
+		return unsetPath(settings, path)
+	})
+}
+
+// Transaction runs fn and applies every change it buffers on tx at once, then
+// refreshes the getter a single time, so readers observe either none or all
+// of the changes. When fn returns an error nothing is applied and the error
+// is returned. The changes are applied to a copy of the current settings,
+// which then replaces them as in ReplaceAll, so the provider must implement
+// contract.Replacer. Every other change to the configuration (SetMap,
+// ApplyOverrides, reloads, ...) waits until the copy has replaced the
+// settings, so none is lost. A later Reload that re-reads the provider's
+// config file discards the changes.
+func (c *Config) Transaction(fn func(tx *Tx) error) error {
+	if err := c.checkFrozen(); err != nil {
+		return err
+	}
+
+	tx := &Tx{}
+	if err := fn(tx); err != nil {
+		return err
+	}
+
+	return c.update(func() error {
+		settings := c.provider.Snapshot()
+		for _, op := range tx.ops {
+			settings = op(settings)
+		}
+
+		return c.replace(settings)
+	})
+}
+
+// splitKey splits a dotted key into the lower-cased path used by providers.
+func splitKey(key string) []string {
+	return strings.Split(strings.ToLower(key), ".")
+}
+
+// mergeSettings returns a copy of settings with m deep-merged into it. Maps
+// along merged paths are copied, so neither input is modified.
+func mergeSettings(settings, m map[string]any) map[string]any {
+	result := maps.Clone(settings)
+	if result == nil {
+		result = make(map[string]any, len(m))
+	}
+
+	for key, value := range m {
+		key = strings.ToLower(key)
+
+		incoming, incomingIsMap := value.(map[string]any)
+		existing, existingIsMap := result[key].(map[string]any)
+
+		if incomingIsMap && existingIsMap {
+			result[key] = mergeSettings(existing, incoming)
+
+			continue
+		}
+
+		result[key] = value
+	}
+
+	return result
+}
+
+// unsetPath returns a copy of settings without the value at path. Maps along
+// the path are copied so the input is never modified.
+func unsetPath(settings map[string]any, path []string) map[string]any {
+	if _, ok := settings[path[0]]; !ok {
+		return settings
+	}
+
+	result := maps.Clone(settings)
+	if len(path) == 1 {
+		delete(result, path[0])
+
+		return result
+	}
+
+	child, ok := result[path[0]].(map[string]any)
+	if !ok {
+		return settings
+	}
+
+	result[path[0]] = unsetPath(child, path[1:])
+
+	return result
+}
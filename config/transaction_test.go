@@ -0,0 +1,145 @@
+package config_test
+
+import (
+	"errors"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/next-trace/scg-config/config"
+	"github.com/next-trace/scg-config/contract"
+)
+
+func TestConfig_Transaction(t *testing.T) {
+	t.Parallel()
+	cfg := config.New(config.WithInitialValues(map[string]any{
+		"db":     map[string]any{"host": "db.old", "port": 5432, "legacy": true},
+		"app":    map[string]any{"name": "demo"},
+		"remove": "me",
+	}))
+
+	err := cfg.Transaction(func(tx *config.Tx) error {
+		tx.Set("db.host", "db.new")
+		tx.Merge(map[string]any{"db": map[string]any{"pool": map[string]any{"size": 10}}})
+		tx.Unset("db.legacy")
+		tx.Unset("remove")
+		tx.Unset("missing.key")
+
+		return nil
+	})
+	require.NoError(t, err)
+
+	host, err := cfg.Get("db.host", contract.String)
+	require.NoError(t, err)
+	require.Equal(t, "db.new", host)
+	require.True(t, cfg.Has("db.pool.size"))
+	require.True(t, cfg.Has("db.port"))
+	require.True(t, cfg.Has("app.name"))
+	require.False(t, cfg.Has("db.legacy"))
+	require.False(t, cfg.Has("remove"))
+
+	// A failing transaction applies nothing.
+	errAbort := errors.New("abort")
+	err = cfg.Transaction(func(tx *config.Tx) error {
+		tx.Set("db.host", "db.partial")
+		tx.Unset("app")
+
+		return errAbort
+	})
+	require.ErrorIs(t, err, errAbort)
+
+	host, err = cfg.Get("db.host", contract.String)
+	require.NoError(t, err)
+	require.Equal(t, "db.new", host)
+	require.True(t, cfg.Has("app.name"))
+}
+
+func TestConfig_Transaction_ReadersNeverSeePartialUpdates(t *testing.T) {
+	t.Parallel()
+	cfg := config.New(config.WithInitialValues(map[string]any{
+		"pair": map[string]any{"a": "0", "b": "0"},
+	}))
+
+	var (
+		done  atomic.Bool
+		wg    sync.WaitGroup
+		torn  atomic.Int32
+		reads atomic.Int32
+	)
+
+	wg.Add(1)
+
+	go func() {
+		defer wg.Done()
+
+		for {
+			pair := cfg.GetAllWithPrefix("pair")
+			if pair["a"] != pair["b"] {
+				torn.Add(1)
+			}
+
+			reads.Add(1)
+
+			if done.Load() {
+				return
+			}
+		}
+	}()
+
+	require.Eventually(t, func() bool { return reads.Load() > 0 }, time.Second, time.Millisecond)
+
+	for i := 1; i <= 200; i++ {
+		value := strconv.Itoa(i)
+		require.NoError(t, cfg.Transaction(func(tx *config.Tx) error {
+			tx.Set("pair.a", value)
+			tx.Set("pair.b", value)
+
+			return nil
+		}))
+	}
+
+	done.Store(true)
+	wg.Wait()
+
+	require.Zero(t, torn.Load(), "reader observed a half-applied transaction")
+}
+
+func TestConfig_Transaction_DoesNotLoseConcurrentWrites(t *testing.T) {
+	t.Parallel()
+	cfg := config.New()
+
+	const writes = 200
+
+	var wg sync.WaitGroup
+
+	wg.Add(2)
+
+	go func() {
+		defer wg.Done()
+
+		for i := range writes {
+			_ = cfg.SetMap(map[string]any{"merged": map[string]any{"k" + strconv.Itoa(i): i}})
+		}
+	}()
+
+	go func() {
+		defer wg.Done()
+
+		for i := range writes {
+			_ = cfg.Transaction(func(tx *config.Tx) error {
+				tx.Set("tx.k"+strconv.Itoa(i), i)
+
+				return nil
+			})
+		}
+	}()
+
+	wg.Wait()
+
+	require.Len(t, cfg.GetAllWithPrefix("merged"), writes)
+	require.Len(t, cfg.GetAllWithPrefix("tx"), writes)
+}
@@ -11,38 +11,63 @@ var (
 	ErrWrongType = errors.New("config: wrong type for key")
 	// ErrUnknownType indicates that an unsupported target KeyType was requested.
 	ErrUnknownType = errors.New("config: unknown type for key")
+	// ErrUnknownProfile indicates that a requested configuration profile does not exist.
+	ErrUnknownProfile = errors.New("config: unknown profile")
+	// ErrOutOfRange indicates that a numeric value lies outside the accepted bounds.
+	ErrOutOfRange = errors.New("config: value out of range")
+	// ErrIndexOutOfRange indicates that a requested slice index is negative or beyond the slice length.
+	ErrIndexOutOfRange = errors.New("config: index out of range")
+	// ErrFrozen indicates an attempt to modify a configuration after Freeze.
+	ErrFrozen = errors.New("config: configuration is frozen")
+	// ErrInvalidOverride indicates a command-line override that is not of the form key=value.
+	ErrInvalidOverride = errors.New("config: invalid override, expected key=value")
 )
 
 // Loader and provider related errors.
 var (
 	// ErrBackendProviderNotSet is returned when an EnvLoader has no backing provider configured.
 	ErrBackendProviderNotSet = errors.New("no provider provider set for environment loader")
+	// ErrInvalidTypedEnv indicates an environment variable whose lowercase type suffix (e.g. APP_PORT__int) is unknown or does not match its value.
+	ErrInvalidTypedEnv = errors.New("invalid typed environment variable")
 	// ErrBackendProviderHasNoConfig is returned when a provider has no config source configured.
 	ErrBackendProviderHasNoConfig = errors.New("provider provider has no config provider set")
 	// ErrReadConfigFileFailed indicates that reading a configuration file failed.
 	ErrReadConfigFileFailed = errors.New("failed to read configuration file")
 	// ErrFailedReadDirectory indicates that reading a configuration directory failed.
 	ErrFailedReadDirectory = errors.New("failed to read directory")
+	// ErrNoConfigFilesFound indicates that a directory required to hold configuration has no supported files.
+	ErrNoConfigFilesFound = errors.New("no supported config files found in directory")
+	// ErrUnsupportedExtension indicates that a configuration file extension or format is not supported.
+	ErrUnsupportedExtension = errors.New("unsupported config file extension")
+	// ErrFormatMismatch indicates that a file's content does not match the format implied by its extension.
+	ErrFormatMismatch = errors.New("config file content does not match its extension")
+	// ErrDecryptFailed indicates that the decrypt function rejected an encrypted configuration file.
+	ErrDecryptFailed = errors.New("failed to decrypt configuration file")
+	// ErrDuplicateKey indicates that a configuration file defines the same key twice.
+	ErrDuplicateKey = errors.New("duplicate key in configuration file")
 )
 
 // Type assertion / conversion errors for getter helpers.
 var (
-	ErrNotInt           = errors.New("not an int")
-	ErrNotInt32         = errors.New("not an int32")
-	ErrNotInt64         = errors.New("not an int64")
-	ErrNotUint          = errors.New("not a uint")
-	ErrNotUint32        = errors.New("not a uint32")
-	ErrNotUint64        = errors.New("not a uint64")
-	ErrNotFloat32       = errors.New("not a float32")
-	ErrNotFloat64       = errors.New("not a float64")
-	ErrNotString        = errors.New("not a string")
-	ErrNotBool          = errors.New("not a bool")
-	ErrNotStringInSlice = errors.New("not a string in slice")
-	ErrNotStringSlice   = errors.New("not a string slice")
-	ErrNotMap           = errors.New("not a map")
-	ErrNotTime          = errors.New("not a time.Time")
-	ErrNotDuration      = errors.New("not a duration")
-	ErrNotBytes         = errors.New("not bytes")
-	ErrNotUUID          = errors.New("not a uuid")
-	ErrNotURL           = errors.New("not a URL")
+	ErrNotInt                  = errors.New("not an int")
+	ErrNotInt32                = errors.New("not an int32")
+	ErrNotInt64                = errors.New("not an int64")
+	ErrNotUint                 = errors.New("not a uint")
+	ErrNotUint32               = errors.New("not a uint32")
+	ErrNotUint64               = errors.New("not a uint64")
+	ErrNotFloat32              = errors.New("not a float32")
+	ErrNotFloat64              = errors.New("not a float64")
+	ErrNotString               = errors.New("not a string")
+	ErrNotBool                 = errors.New("not a bool")
+	ErrNotStringInSlice        = errors.New("not a string in slice")
+	ErrNotStringSlice          = errors.New("not a string slice")
+	ErrNotSlice                = errors.New("not a slice")
+	ErrNotMap                  = errors.New("not a map")
+	ErrNotStringMapStringSlice = errors.New("not a map of string slices")
+	ErrNotTime                 = errors.New("not a time.Time")
+	ErrNotDuration             = errors.New("not a duration")
+	ErrNotDurationSlice        = errors.New("not a duration slice")
+	ErrNotBytes                = errors.New("not bytes")
+	ErrNotUUID                 = errors.New("not a uuid")
+	ErrNotURL                  = errors.New("not a URL")
 )
@@ -2,6 +2,8 @@
 // configuration system.
 package contract
 
+import "strings"
+
 // File extensions for supported config formats.
 const (
 	ExtYAML = ".yaml"
@@ -14,25 +16,57 @@ type KeyType string
 
 // KeyType constants enumerate the supported target types for configuration values.
 const (
-	Int         KeyType = "int"
-	Int32       KeyType = "int32"
-	Int64       KeyType = "int64"
-	Uint        KeyType = "uint"
-	Uint32      KeyType = "uint32"
-	Uint64      KeyType = "uint64"
-	Float32     KeyType = "float32"
-	Float64     KeyType = "float64"
-	String      KeyType = "string"
-	Bool        KeyType = "bool"
-	StringSlice KeyType = "[]string"
-	Map         KeyType = "map"
-	Time        KeyType = "time"
-	Duration    KeyType = "duration"
-	Bytes       KeyType = "bytes"
-	UUID        KeyType = "uuid"
-	URL         KeyType = "url"
+	Int                  KeyType = "int"
+	Int32                KeyType = "int32"
+	Int64                KeyType = "int64"
+	Uint                 KeyType = "uint"
+	Uint32               KeyType = "uint32"
+	Uint64               KeyType = "uint64"
+	Float32              KeyType = "float32"
+	Float64              KeyType = "float64"
+	String               KeyType = "string"
+	Bool                 KeyType = "bool"
+	StringSlice          KeyType = "[]string"
+	Map                  KeyType = "map"
+	StringMapStringSlice KeyType = "map[string][]string"
+	StringMapInt         KeyType = "map[string]int"
+	Time                 KeyType = "time"
+	Duration             KeyType = "duration"
+	DurationSlice        KeyType = "[]duration"
+	Bytes                KeyType = "bytes"
+	UUID                 KeyType = "uuid"
+	URL                  KeyType = "url"
 )
 
+// Prefixes of composite KeyTypes built by SliceOf and MapOf.
+const (
+	slicePrefix = "[]"
+	mapPrefix   = "map[string]"
+)
+
+// SliceOf returns the KeyType of a list whose elements each convert as elem,
+// e.g. SliceOf(Duration) for []time.Duration or SliceOf(Int) for []int. The
+// named slice types are composites too: SliceOf(String) is StringSlice.
+func SliceOf(elem KeyType) KeyType { return slicePrefix + elem }
+
+// MapOf returns the KeyType of a map with string keys whose values each
+// convert as elem, e.g. MapOf(Int) (equal to StringMapInt) or MapOf(Bool).
+func MapOf(elem KeyType) KeyType { return mapPrefix + elem }
+
+// Elem splits a composite KeyType built by SliceOf or MapOf into its element
+// KeyType, reporting whether it is a slice. ok is false for other KeyTypes.
+func (t KeyType) Elem() (elem KeyType, isSlice, ok bool) {
+	if rest, found := strings.CutPrefix(string(t), slicePrefix); found && rest != "" {
+		return KeyType(rest), true, true
+	}
+
+	if rest, found := strings.CutPrefix(string(t), mapPrefix); found && rest != "" {
+		return KeyType(rest), false, true
+	}
+
+	return "", false, false
+}
+
 // ValueAccessor is the type-safe accessor API for retrieving config values.
 type ValueAccessor interface {
 	Get(key string, typ KeyType) (any, error)
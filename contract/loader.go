@@ -8,9 +8,27 @@ type EnvLoader interface {
 	GetProvider() Provider
 }
 
+// EnvReloader is optionally implemented by env loaders that can load the
+// environment again for the prefixes loaded so far. Config.ReloadOnSignal uses
+// it so env-only deployments pick up changed variables.
+type EnvReloader interface {
+	ReloadFromEnv() error
+}
+
 // FileLoader describes loading configuration from files and directories.
 type FileLoader interface {
 	LoadFromFile(configFile string) error
 	LoadFromDirectory(dir string) error
 	GetProvider() Provider
 }
+
+// FormatParser parses configuration files of an additional format, such as
+// TOML or INI, for the file loader. Register it with file.RegisterParser.
+type FormatParser interface {
+	// Extensions returns the file extensions handled by the parser, with the
+	// leading dot (e.g. ".toml").
+	Extensions() []string
+
+	// Parse decodes data into a nested configuration map.
+	Parse(data []byte) (map[string]any, error)
+}
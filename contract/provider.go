@@ -2,6 +2,8 @@
 // configuration system.
 package contract
 
+import "context"
+
 // Provider is the abstraction over the underlying configuration backend.
 type Provider interface {
 	// ReadInConfig Loads/reloads config if supported by backend.
@@ -10,6 +12,11 @@ type Provider interface {
 	// AllSettings Returns the config as a nested map.
 	AllSettings() map[string]interface{}
 
+	// Snapshot Returns the config as a nested map like AllSettings, but as a deep
+	// copy that shares no storage with the backend: mutating the result (or any
+	// map or slice inside it) never changes the provider.
+	Snapshot() map[string]interface{}
+
 	// GetKey Flat/fast lookup for a single key (optional, for Provider tests/debug).
 	GetKey(key string) any
 
@@ -17,12 +24,78 @@ type Provider interface {
 	Set(key string, value any)
 
 	// IsSet Returns true if this key exists (flat only, for legacy/quick lookups).
+	// It is a low-level backend check and may not resolve nested dot paths for
+	// every provider; use Config.IsSet (or Config.Has) as the canonical check.
 	IsSet(key string) bool
 
 	// Provider For advanced direct backend use.
 	Provider() any
 
+	// ConfigFilesUsed Returns the configuration files the settings were read
+	// from, in load order: the file set via SetConfigFile followed by files
+	// merged into it (see ConfigFileTracker). Empty when no file was loaded.
+	ConfigFilesUsed() []string
+
 	// SetConfigFile Optionally, for changing file, merging maps, etc.
 	SetConfigFile(file string)
 	MergeConfigMap(cfg map[string]interface{}) error
 }
+
+// Origin labels and prefixes used by OriginTracker implementations.
+const (
+	// OriginSet labels values written at runtime via Provider.Set.
+	OriginSet = "set"
+	// OriginEnvPrefix prefixes origins of values loaded from environment variables (e.g. "env:APP_NAME").
+	OriginEnvPrefix = "env:"
+	// OriginFilePrefix prefixes origins of values loaded from files (e.g. "file:/etc/app.yaml").
+	OriginFilePrefix = "file:"
+)
+
+// OriginTracker is optionally implemented by providers that record where each
+// key's effective value came from. Loaders tag the keys they set so callers can
+// debug precedence surprises.
+type OriginTracker interface {
+	// RecordOrigin records source as the origin of key.
+	RecordOrigin(key, source string)
+
+	// Origin returns the origin of the effective value of key, if known.
+	Origin(key string) (string, bool)
+}
+
+// ConfigFileTracker is optionally implemented by providers that report the
+// files merged into them through ConfigFilesUsed. File loaders record every
+// file they merge on top of the one set via SetConfigFile.
+type ConfigFileTracker interface {
+	// RecordConfigFile records path as merged into the provider's settings.
+	RecordConfigFile(path string)
+}
+
+// Replacer is optionally implemented by providers that can swap all of their
+// settings at once. Config.ReplaceAll and the methods built on it require it.
+type Replacer interface {
+	// Replace discards every setting and loads configMap in their place, so
+	// keys missing from configMap are gone afterwards.
+	Replace(configMap map[string]interface{}) error
+}
+
+// LazyProvider is optionally implemented by providers that fetch values on
+// demand, e.g. from a remote store too large to snapshot. Config.GetCtx
+// consults it for keys missing from the snapshot.
+type LazyProvider interface {
+	// GetLazy fetches the value of key. found is false when the key does not
+	// exist; err reports a failed fetch.
+	GetLazy(ctx context.Context, key string) (value any, found bool, err error)
+}
+
+// StreamProvider is optionally implemented by providers fed by a push source,
+// e.g. a centralized config service streaming snapshots over gRPC.
+// Config.StartStreaming turns every pushed snapshot into a reload, and
+// Config.Close closes the stream.
+type StreamProvider interface {
+	// Updates returns a channel signaling that a new snapshot was pushed; the
+	// next ReadInConfig applies it. The channel is closed when the stream ends.
+	Updates() <-chan map[string]any
+
+	// Close ends the stream and releases its resources.
+	Close() error
+}
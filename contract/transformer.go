@@ -0,0 +1,17 @@
+package contract
+
+// ValueTransformer rewrites a raw configuration value after it has been
+// resolved and before it is converted to the requested type, e.g. to decrypt
+// secrets stored encrypted in a config file.
+type ValueTransformer interface {
+	// Transform returns the value to use for key in place of raw.
+	Transform(key string, raw any) (any, error)
+}
+
+// ValueTransformerFunc adapts a function to the ValueTransformer interface.
+type ValueTransformerFunc func(key string, raw any) (any, error)
+
+// Transform calls f(key, raw).
+func (f ValueTransformerFunc) Transform(key string, raw any) (any, error) {
+	return f(key, raw)
+}
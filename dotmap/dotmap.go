@@ -125,3 +125,42 @@ func resolveInterfaceMap(configData map[interface{}]interface{}, key string, cas
 
 	return nil, false
 }
+
+// Flatten converts a nested map into a flat map keyed by dot-notation paths to
+// its leaf values (e.g. {"app": {"name": "x"}} becomes {"app.name": "x"}).
+// Slices are treated as leaf values.
+func Flatten(settings map[string]interface{}) map[string]interface{} {
+	flat := make(map[string]interface{})
+	flattenInto(flat, "", settings)
+
+	return flat
+}
+
+// flattenInto recursively writes the leaves of value into flat under prefix.
+func flattenInto(flat map[string]interface{}, prefix string, value interface{}) {
+	switch typedMap := value.(type) {
+	case map[string]interface{}:
+		for key, child := range typedMap {
+			flattenInto(flat, joinPath(prefix, key), child)
+		}
+	case map[interface{}]interface{}:
+		for key, child := range typedMap {
+			if keyString, ok := key.(string); ok {
+				flattenInto(flat, joinPath(prefix, keyString), child)
+			}
+		}
+	default:
+		if prefix != "" {
+			flat[prefix] = value
+		}
+	}
+}
+
+// joinPath appends key to the dot-notation prefix.
+func joinPath(prefix, key string) string {
+	if prefix == "" {
+		return key
+	}
+
+	return prefix + "." + key
+}
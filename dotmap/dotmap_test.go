@@ -78,3 +78,31 @@ func TestResolver(t *testing.T) {
 		})
 	}
 }
+
+func TestFlatten(t *testing.T) {
+	t.Parallel()
+
+	settings := map[string]interface{}{
+		"app": map[string]interface{}{
+			"name": "scg",
+			"db":   map[interface{}]interface{}{"host": "localhost"},
+			"tags": []interface{}{"a", "b"},
+		},
+		"debug": true,
+	}
+
+	want := map[string]interface{}{
+		"app.name":    "scg",
+		"app.db.host": "localhost",
+		"app.tags":    []interface{}{"a", "b"},
+		"debug":       true,
+	}
+
+	if got := dotmap.Flatten(settings); !reflect.DeepEqual(got, want) {
+		t.Errorf("Flatten() = %v, want %v", got, want)
+	}
+
+	if got := dotmap.Flatten(nil); len(got) != 0 {
+		t.Errorf("Flatten(nil) = %v, want empty", got)
+	}
+}
@@ -0,0 +1,144 @@
+package dotmap
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Entry is a single key/value pair of an OrderedMap.
+type Entry struct {
+	Key   string
+	Value interface{}
+}
+
+// OrderedMap is a map represented as entries sorted by key. It serializes to
+// JSON and YAML as a regular mapping, with keys in a stable order.
+type OrderedMap []Entry
+
+// Sorted converts settings into an OrderedMap, recursively sorting the keys of
+// nested maps (including map[interface{}]interface{}). Slices keep their order;
+// maps inside slices are sorted as well.
+func Sorted(settings map[string]interface{}) OrderedMap {
+	ordered := make(OrderedMap, 0, len(settings))
+	for key, value := range settings {
+		ordered = append(ordered, Entry{Key: key, Value: sortedValue(value)})
+	}
+
+	sort.Slice(ordered, func(i, j int) bool { return ordered[i].Key < ordered[j].Key })
+
+	return ordered
+}
+
+// SortedKeys returns the keys of settings in ascending order, e.g. to iterate
+// the result of Flatten deterministically.
+func SortedKeys(settings map[string]interface{}) []string {
+	keys := make([]string, 0, len(settings))
+	for key := range settings {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+// sortedValue converts nested maps within value to OrderedMaps.
+func sortedValue(value interface{}) interface{} {
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		return Sorted(typed)
+	case map[interface{}]interface{}:
+		converted := make(map[string]interface{}, len(typed))
+		for key, child := range typed {
+			converted[fmt.Sprint(key)] = child
+		}
+
+		return Sorted(converted)
+	case []interface{}:
+		items := make([]interface{}, len(typed))
+		for i, child := range typed {
+			items[i] = sortedValue(child)
+		}
+
+		return items
+	default:
+		return value
+	}
+}
+
+// Keys returns the keys of the map in order.
+func (o OrderedMap) Keys() []string {
+	keys := make([]string, len(o))
+	for i, entry := range o {
+		keys[i] = entry.Key
+	}
+
+	return keys
+}
+
+// Get returns the value stored under key.
+func (o OrderedMap) Get(key string) (interface{}, bool) {
+	for _, entry := range o {
+		if entry.Key == key {
+			return entry.Value, true
+		}
+	}
+
+	return nil, false
+}
+
+// MarshalJSON encodes the map as a JSON object with keys in order.
+func (o OrderedMap) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+
+	buf.WriteByte('{')
+
+	for i, entry := range o {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+
+		key, err := json.Marshal(entry.Key)
+		if err != nil {
+			return nil, fmt.Errorf("dotmap: marshal key %q: %w", entry.Key, err)
+		}
+
+		value, err := json.Marshal(entry.Value)
+		if err != nil {
+			return nil, fmt.Errorf("dotmap: marshal value of %q: %w", entry.Key, err)
+		}
+
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+
+	buf.WriteByte('}')
+
+	return buf.Bytes(), nil
+}
+
+// MarshalYAML encodes the map as a YAML mapping with keys in order.
+func (o OrderedMap) MarshalYAML() (interface{}, error) {
+	node := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+
+	for _, entry := range o {
+		var key, value yaml.Node
+
+		if err := key.Encode(entry.Key); err != nil {
+			return nil, fmt.Errorf("dotmap: marshal key %q: %w", entry.Key, err)
+		}
+
+		if err := value.Encode(entry.Value); err != nil {
+			return nil, fmt.Errorf("dotmap: marshal value of %q: %w", entry.Key, err)
+		}
+
+		node.Content = append(node.Content, &key, &value)
+	}
+
+	return node, nil
+}
@@ -0,0 +1,69 @@
+package dotmap_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/next-trace/scg-config/dotmap"
+)
+
+func orderedFixture() map[string]interface{} {
+	return map[string]interface{}{
+		"server": map[string]interface{}{"port": 8080, "host": "h"},
+		"app":    map[interface{}]interface{}{"z": 1, "a": 2},
+		"list":   []interface{}{"c", map[string]interface{}{"x": 1, "b": 2}, "a"},
+	}
+}
+
+func TestSorted(t *testing.T) {
+	t.Parallel()
+
+	ordered := dotmap.Sorted(orderedFixture())
+
+	if got, want := ordered.Keys(), []string{"app", "list", "server"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("keys = %v, want %v", got, want)
+	}
+
+	server, ok := ordered.Get("server")
+	if !ok || !reflect.DeepEqual(server.(dotmap.OrderedMap).Keys(), []string{"host", "port"}) {
+		t.Fatalf("server not sorted: %v", server)
+	}
+
+	if _, ok := ordered.Get("missing"); ok {
+		t.Fatal("missing key reported as present")
+	}
+
+	jsonOut, err := json.Marshal(ordered)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wantJSON := `{"app":{"a":2,"z":1},"list":["c",{"b":2,"x":1},"a"],"server":{"host":"h","port":8080}}`
+	if string(jsonOut) != wantJSON {
+		t.Fatalf("json = %s, want %s", jsonOut, wantJSON)
+	}
+
+	yamlOut, err := yaml.Marshal(ordered)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wantYAML := "app:\n    a: 2\n    z: 1\nlist:\n    - c\n    - b: 2\n      x: 1\n    - a\nserver:\n    host: h\n    port: 8080\n"
+	if string(yamlOut) != wantYAML {
+		t.Fatalf("yaml = %q, want %q", yamlOut, wantYAML)
+	}
+}
+
+func TestSortedKeys(t *testing.T) {
+	t.Parallel()
+
+	keys := dotmap.SortedKeys(dotmap.Flatten(orderedFixture()))
+	want := []string{"app.a", "app.z", "list", "server.host", "server.port"}
+
+	if !reflect.DeepEqual(keys, want) {
+		t.Fatalf("keys = %v, want %v", keys, want)
+	}
+}
@@ -2,48 +2,272 @@
 package env
 
 import (
+	"fmt"
 	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/next-trace/scg-config/configerrors"
 	"github.com/next-trace/scg-config/contract"
 	"github.com/next-trace/scg-config/utils"
 )
 
+// DefaultNestingSeparator is the separator that denotes nesting in env keys by default.
+const DefaultNestingSeparator = "_"
+
+// typeSuffixSeparator separates a type suffix from an env key, as in APP_PORT__int.
+const typeSuffixSeparator = "__"
+
 // Loader loads configuration from environment variables into the provider provider.
 type Loader struct {
-	provider contract.Provider
+	provider      contract.Provider
+	separator     string
+	bindings      map[string]string
+	typedSuffixes bool
+	prefixes      []string
+	mu            sync.Mutex
+}
+
+// Option is a functional option for configuring the Loader.
+type Option func(*Loader)
+
+// WithNestingSeparator sets the separator that denotes nesting in env keys.
+// With "__", APP__DB__HOST maps to db.host while single underscores within a
+// segment are preserved (APP__DB__MAX_CONNS maps to db.max_conns).
+func WithNestingSeparator(separator string) Option {
+	return func(l *Loader) { l.separator = separator }
+}
+
+// WithEnvBindings makes the Loader record which environment variable produced
+// each config key, available via EnvBindings. Recording is off by default.
+func WithEnvBindings() Option {
+	return func(l *Loader) { l.bindings = make(map[string]string) }
+}
+
+// WithTypedSuffixes makes the Loader read a "__type" suffix on env keys as the
+// value's type: APP_PORT__int=8080 sets port to the int 8080 and
+// APP_TAGS__csv=a,b,c sets tags to []string{"a", "b", "c"}. Supported types
+// are int, bool, float (float64), csv ([]string) and duration, matched
+// case-insensitively; the suffix is not part of the resulting key. Any other
+// trailing "__" segment written in lowercase letters, such as the typo in
+// APP_PORT__itn, is an unknown type suffix. An unknown suffix or a value that
+// does not parse as its type makes the load fail with ErrInvalidTypedEnv before
+// anything is set. Other segments (e.g. APP_CACHE__DIR, or the nesting in
+// APP__DB__HOST under WithNestingSeparator("__")) stay part of the key.
+func WithTypedSuffixes() Option {
+	return func(l *Loader) { l.typedSuffixes = true }
 }
 
 // NewEnvLoader creates a new Loader for the given provider provider.
-func NewEnvLoader(p contract.Provider) *Loader {
-	return &Loader{provider: p}
+func NewEnvLoader(p contract.Provider, opts ...Option) *Loader {
+	loader := &Loader{provider: p, separator: DefaultNestingSeparator}
+	for _, opt := range opts {
+		opt(loader)
+	}
+
+	return loader
 }
 
 // LoadFromEnv loads environment variables with the given prefix into the provider.
 // Prefix is stripped and keys are normalized to dot notation (e.g. APP_NAME -> app.name).
+// The prefix is remembered for ReloadFromEnv.
 func (el *Loader) LoadFromEnv(prefix string) error {
+	if err := el.LoadFromEnvSlice(prefix, os.Environ()); err != nil {
+		return err
+	}
+
+	el.mu.Lock()
+	defer el.mu.Unlock()
+
+	// Keep each prefix once, in the order it was last loaded, so a reload
+	// resolves keys shared by several prefixes the same way.
+	for i, loaded := range el.prefixes {
+		if loaded == prefix {
+			el.prefixes = append(el.prefixes[:i], el.prefixes[i+1:]...)
+
+			break
+		}
+	}
+
+	el.prefixes = append(el.prefixes, prefix)
+
+	return nil
+}
+
+// ReloadFromEnv loads the environment again for every prefix passed to
+// LoadFromEnv so far, so changed variables reach the provider. Variables that
+// were removed from the environment keep their last value. Config uses it via
+// contract.EnvReloader when reloading on a signal.
+func (el *Loader) ReloadFromEnv() error {
+	el.mu.Lock()
+	prefixes := append([]string(nil), el.prefixes...)
+	el.mu.Unlock()
+
+	env := os.Environ()
+	for _, prefix := range prefixes {
+		if err := el.LoadFromEnvSlice(prefix, env); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// LoadFromEnvSlice behaves like LoadFromEnv but reads variables from env, a
+// slice of "KEY=VALUE" entries in the format of os.Environ. This allows loading
+// a curated environment and testing without modifying the process env.
+func (el *Loader) LoadFromEnvSlice(prefix string, env []string) error {
 	provider := el.provider
 	if provider == nil {
 		return configerrors.ErrBackendProviderNotSet
 	}
 
-	prefix = utils.NormalizePrefix(prefix)
+	prefix = utils.NormalizePrefixWithSeparator(prefix, el.separator)
 
-	for _, envString := range os.Environ() {
+	type entry struct {
+		envName, key string
+		value        any
+	}
+
+	var entries []entry
+
+	for _, envString := range env {
 		if !utils.ShouldProcessEnv(envString, prefix) {
 			continue
 		}
 
-		key, value := utils.SplitEnv(envString)
-		key = utils.StripPrefix(key, prefix)
-		key = utils.NormalizeEnvKey(key)
+		envName, raw := utils.SplitEnv(envString)
+		name := utils.StripPrefix(envName, prefix)
+
+		var value any = raw
+
+		if el.typedSuffixes {
+			var err error
+			if name, value, err = el.typedValue(envName, name, raw); err != nil {
+				return err
+			}
+		}
 
-		provider.Set(key, value)
+		// A variable named just the prefix (e.g. APP_) normalizes to no key.
+		key := utils.NormalizeEnvKeyWithSeparator(name, el.separator)
+		if key == "" {
+			continue
+		}
+
+		entries = append(entries, entry{envName: envName, key: key, value: value})
+	}
+
+	el.mu.Lock()
+	defer el.mu.Unlock()
+
+	for _, e := range entries {
+		provider.Set(e.key, e.value)
+
+		if el.bindings != nil {
+			el.bindings[e.key] = e.envName
+		}
+
+		if tracker, ok := provider.(contract.OriginTracker); ok {
+			tracker.RecordOrigin(e.key, contract.OriginEnvPrefix+e.envName)
+		}
+	}
+
+	return nil
+}
+
+// typedValue strips a "__type" suffix from name and converts raw to that type.
+// Names without a type suffix are returned with raw unchanged.
+func (el *Loader) typedValue(envName, name, raw string) (string, any, error) {
+	idx := strings.LastIndex(name, typeSuffixSeparator)
+	if idx <= 0 {
+		return name, raw, nil
+	}
+
+	base, suffix := name[:idx], name[idx+len(typeSuffixSeparator):]
+	typ := strings.ToLower(suffix)
+
+	var (
+		value any
+		err   error
+	)
+
+	switch typ {
+	case "int":
+		value, err = strconv.Atoi(raw)
+	case "bool":
+		value, err = strconv.ParseBool(raw)
+	case "float":
+		value, err = strconv.ParseFloat(raw, 64)
+	case "csv":
+		value = utils.SplitList(raw, "")
+	case "duration":
+		value, err = time.ParseDuration(raw)
+	default:
+		if !isLowerWord(suffix) {
+			return name, raw, nil
+		}
+
+		return "", nil, fmt.Errorf("%w: %s: unknown type suffix %q (want int, bool, float, csv or duration)",
+			configerrors.ErrInvalidTypedEnv, envName, suffix)
+	}
+
+	if err != nil {
+		return "", nil, fmt.Errorf("%w: %s: %w", configerrors.ErrInvalidTypedEnv, envName, err)
+	}
+
+	return base, value, nil
+}
+
+// isLowerWord reports whether s is made of lowercase ASCII letters only, the
+// form reserved for type suffixes.
+func isLowerWord(s string) bool {
+	if s == "" {
+		return false
+	}
+
+	for _, r := range s {
+		if r < 'a' || r > 'z' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// LoadFromEnvPrefixes loads environment variables for each prefix in order,
+// as LoadFromEnv does. When a key is reachable under several prefixes (e.g.
+// APP_DB_HOST and SERVICE_DB_HOST), the value of the last prefix wins.
+func (el *Loader) LoadFromEnvPrefixes(prefixes ...string) error {
+	for _, prefix := range prefixes {
+		if err := el.LoadFromEnv(prefix); err != nil {
+			return err
+		}
 	}
 
 	return nil
 }
 
+// EnvBindings returns a copy of the recorded config key to environment
+// variable name mapping (e.g. "app.name" -> "APP_APP_NAME"). It is nil unless
+// the Loader was created with WithEnvBindings.
+func (el *Loader) EnvBindings() map[string]string {
+	el.mu.Lock()
+	defer el.mu.Unlock()
+
+	if el.bindings == nil {
+		return nil
+	}
+
+	bindings := make(map[string]string, len(el.bindings))
+	for key, envName := range el.bindings {
+		bindings[key] = envName
+	}
+
+	return bindings
+}
+
 // GetProvider returns the Provider associated with the Loader.
 //
 //nolint:ireturn // returning an interface is required by the contract API
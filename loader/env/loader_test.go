@@ -2,11 +2,13 @@ package env_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
 	"github.com/next-trace/scg-config/configerrors"
 	"github.com/next-trace/scg-config/loader/env"
+	"github.com/next-trace/scg-config/provider/viper"
 )
 
 func TestEnvLoader_NilProvider_Error(t *testing.T) {
@@ -16,3 +18,179 @@ func TestEnvLoader_NilProvider_Error(t *testing.T) {
 	require.Error(t, err)
 	require.ErrorIs(t, err, configerrors.ErrBackendProviderNotSet)
 }
+
+func TestEnvLoader_DefaultSeparator(t *testing.T) {
+	// Note: Cannot use t.Parallel() with t.Setenv()
+	t.Setenv("SEPTEST_DB_HOST", "localhost")
+
+	p := viper.NewConfigProvider()
+	require.NoError(t, env.NewEnvLoader(p).LoadFromEnv("septest"))
+	require.Equal(t, "localhost", p.GetKey("db.host"))
+}
+
+func TestEnvLoader_ReloadFromEnv(t *testing.T) {
+	// Note: Cannot use t.Parallel() with t.Setenv()
+	t.Setenv("RELOADA_DB_HOST", "a-db")
+	t.Setenv("RELOADB_DB_HOST", "b-db")
+
+	p := viper.NewConfigProvider()
+	ldr := env.NewEnvLoader(p)
+	require.NoError(t, ldr.LoadFromEnv("reloadb"))
+	require.NoError(t, ldr.LoadFromEnv("reloada"))
+	require.NoError(t, ldr.LoadFromEnv("reloadb"))
+	require.Equal(t, "b-db", p.GetKey("db.host"))
+
+	t.Setenv("RELOADA_DB_PORT", "5432")
+	t.Setenv("RELOADB_DB_HOST", "b-db-2")
+	require.NoError(t, ldr.ReloadFromEnv())
+	require.Equal(t, "b-db-2", p.GetKey("db.host"), "the last loaded prefix still wins")
+	require.Equal(t, "5432", p.GetKey("db.port"))
+}
+
+func TestEnvLoader_WithNestingSeparator(t *testing.T) {
+	// Note: Cannot use t.Parallel() with t.Setenv()
+	t.Setenv("NESTTEST__DB__HOST", "localhost")
+	t.Setenv("NESTTEST__DB__MAX_CONNS", "10")
+
+	p := viper.NewConfigProvider()
+	ldr := env.NewEnvLoader(p, env.WithNestingSeparator("__"))
+	require.NoError(t, ldr.LoadFromEnv("nesttest"))
+
+	all := p.AllSettings()
+	db, ok := all["db"].(map[string]any)
+	require.True(t, ok)
+	require.Equal(t, "localhost", db["host"])
+	require.Equal(t, "10", db["max_conns"])
+}
+
+func TestEnvLoader_LoadFromEnvPrefixes(t *testing.T) {
+	// Note: Cannot use t.Parallel() with t.Setenv()
+	t.Setenv("PFXAPP_APP_NAME", "demo")
+	t.Setenv("PFXAPP_DB_HOST", "app-db")
+	t.Setenv("PFXSVC_SERVICE_PORT", "8080")
+	t.Setenv("PFXSVC_DB_HOST", "service-db")
+
+	p := viper.NewConfigProvider()
+	require.NoError(t, env.NewEnvLoader(p).LoadFromEnvPrefixes("pfxapp", "pfxsvc"))
+	require.Equal(t, "demo", p.GetKey("app.name"))
+	require.Equal(t, "8080", p.GetKey("service.port"))
+	require.Equal(t, "service-db", p.GetKey("db.host"))
+
+	// Reversing the order reverses the precedence.
+	p = viper.NewConfigProvider()
+	require.NoError(t, env.NewEnvLoader(p).LoadFromEnvPrefixes("pfxsvc", "pfxapp"))
+	require.Equal(t, "app-db", p.GetKey("db.host"))
+
+	require.ErrorIs(t, env.NewEnvLoader(nil).LoadFromEnvPrefixes("a"), configerrors.ErrBackendProviderNotSet)
+}
+
+func TestEnvLoader_EnvBindings(t *testing.T) {
+	// Note: Cannot use t.Parallel() with t.Setenv()
+	t.Setenv("BINDTEST_APP_NAME", "demo")
+	t.Setenv("BINDTEST_DB_HOST", "db")
+
+	p := viper.NewConfigProvider()
+	ldr := env.NewEnvLoader(p, env.WithEnvBindings())
+	require.NoError(t, ldr.LoadFromEnv("bindtest"))
+	require.Equal(t, map[string]string{
+		"app.name": "BINDTEST_APP_NAME",
+		"db.host":  "BINDTEST_DB_HOST",
+	}, ldr.EnvBindings())
+
+	// Recording is opt-in.
+	plain := env.NewEnvLoader(p)
+	require.NoError(t, plain.LoadFromEnv("bindtest"))
+	require.Nil(t, plain.EnvBindings())
+}
+
+func TestEnvLoader_LoadFromEnvSlice(t *testing.T) {
+	t.Parallel()
+
+	vars := []string{
+		"SLICE_APP_NAME=demo",
+		"SLICE_DB_URL=postgres://u:p@h/db?sslmode=disable",
+		"SLICE_EMPTY=",
+		"OTHER_APP_NAME=ignored",
+	}
+
+	p := viper.NewConfigProvider()
+	ldr := env.NewEnvLoader(p, env.WithEnvBindings())
+	require.NoError(t, ldr.LoadFromEnvSlice("slice", vars))
+	require.Equal(t, "demo", p.GetKey("app.name"))
+	require.Equal(t, "postgres://u:p@h/db?sslmode=disable", p.GetKey("db.url"))
+	require.Equal(t, "", p.GetKey("empty"))
+	require.Equal(t, "SLICE_APP_NAME", ldr.EnvBindings()["app.name"])
+	require.Len(t, ldr.EnvBindings(), 3)
+
+	require.ErrorIs(t, env.NewEnvLoader(nil).LoadFromEnvSlice("slice", vars), configerrors.ErrBackendProviderNotSet)
+}
+
+func TestEnvLoader_LoadFromEnvSlice_SkipsEmptyKeys(t *testing.T) {
+	t.Parallel()
+	prov := viper.NewConfigProvider(viper.WithoutAutomaticEnv())
+
+	require.NoError(t, env.NewEnvLoader(prov).LoadFromEnvSlice("app", []string{"APP_=x", "APP___=y", "APP_NAME=n"}))
+	require.Equal(t, map[string]any{"name": "n"}, prov.AllSettings())
+
+	nested := viper.NewConfigProvider(viper.WithoutAutomaticEnv())
+	require.NoError(t, env.NewEnvLoader(nested, env.WithNestingSeparator("__")).
+		LoadFromEnvSlice("app", []string{"APP__=x", "APP__NAME=n"}))
+	require.Equal(t, map[string]any{"name": "n"}, nested.AllSettings())
+}
+
+func TestEnvLoader_TypedSuffixes(t *testing.T) {
+	t.Parallel()
+	prov := viper.NewConfigProvider()
+	ldr := env.NewEnvLoader(prov, env.WithTypedSuffixes())
+
+	require.NoError(t, ldr.LoadFromEnvSlice("typed", []string{
+		"TYPED_SERVER_PORT__int=8080",
+		"TYPED_DEBUG__BOOL=true",
+		"TYPED_RATIO__float=0.25",
+		"TYPED_TAGS__csv=a, b,c",
+		"TYPED_TIMEOUT__duration=1m30s",
+		"TYPED_NAME=plain",
+	}))
+
+	require.Equal(t, 8080, prov.GetKey("server.port"))
+	require.Equal(t, true, prov.GetKey("debug"))
+	require.InDelta(t, 0.25, prov.GetKey("ratio"), 1e-9)
+	require.Equal(t, []string{"a", "b", "c"}, prov.GetKey("tags"))
+	require.Equal(t, 90*time.Second, prov.GetKey("timeout"))
+	require.Equal(t, "plain", prov.GetKey("name"))
+	require.False(t, prov.IsSet("server.port__int"))
+}
+
+func TestEnvLoader_TypedSuffixes_Errors(t *testing.T) {
+	t.Parallel()
+	prov := viper.NewConfigProvider()
+	ldr := env.NewEnvLoader(prov, env.WithTypedSuffixes())
+
+	err := ldr.LoadFromEnvSlice("bad", []string{"BAD_NAME=ok", "BAD_PORT__int=eighty"})
+	require.ErrorIs(t, err, configerrors.ErrInvalidTypedEnv)
+	require.Contains(t, err.Error(), "BAD_PORT__int")
+	require.False(t, prov.IsSet("name"), "nothing is set when a variable is invalid")
+
+	err = ldr.LoadFromEnvSlice("bad", []string{"BAD_NAME=ok", "BAD_PORT__itn=8080"})
+	require.ErrorIs(t, err, configerrors.ErrInvalidTypedEnv)
+	require.Contains(t, err.Error(), "BAD_PORT__itn")
+	require.Contains(t, err.Error(), `unknown type suffix "itn"`)
+	require.False(t, prov.IsSet("name"))
+
+	// Segments that are not all lowercase letters are not type suffixes.
+	require.NoError(t, ldr.LoadFromEnvSlice("bad", []string{"BAD_CACHE__DIR=/tmp", "BAD_SLOT__2=b"}))
+	require.Equal(t, "/tmp", prov.GetKey("cache.dir"))
+	require.Equal(t, "b", prov.GetKey("slot.2"))
+
+	// Without the option the suffix is an ordinary part of the key.
+	plain := viper.NewConfigProvider()
+	require.NoError(t, env.NewEnvLoader(plain).LoadFromEnvSlice("bad", []string{"BAD_PORT__int=8080"}))
+	require.Equal(t, "8080", plain.GetKey("port.int"))
+
+	// With a "__" nesting separator only known type names are suffixes.
+	nested := viper.NewConfigProvider()
+	require.NoError(t, env.NewEnvLoader(nested, env.WithTypedSuffixes(), env.WithNestingSeparator("__")).
+		LoadFromEnvSlice("app", []string{"APP__DB__PORT__int=5432", "APP__DB__HOST=db"}))
+	require.Equal(t, 5432, nested.GetKey("db.port"))
+	require.Equal(t, "db", nested.GetKey("db.host"))
+}
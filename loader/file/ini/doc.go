@@ -0,0 +1,6 @@
+// Package ini provides a contract.FormatParser for INI files, as emitted by
+// legacy services. Register it with the file loader to load .ini and .cfg
+// files:
+//
+//	file.RegisterParser(ini.Parser{})
+package ini
@@ -0,0 +1,89 @@
+package ini
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/next-trace/scg-config/contract"
+)
+
+// ErrSyntax indicates an INI line that is neither a section header, a
+// key = value pair nor a comment.
+var ErrSyntax = errors.New("ini: syntax error")
+
+// Parser parses INI files into nested maps. Every [section] becomes a
+// top-level map (a dotted name such as [db.primary] nests further) holding
+// the section's key = value pairs; keys before the first section go to the
+// top level. Values are split on the first "=", so they may contain "=", and
+// are kept as strings with surrounding quotes removed; Load and the typed
+// getters convert them. Lines starting with ";" or "#" are comments; inline
+// comments are not recognized, so "#" and ";" may appear in values.
+type Parser struct{}
+
+// Extensions returns the extensions handled by the parser: .ini and .cfg.
+func (Parser) Extensions() []string {
+	return []string{".ini", ".cfg"}
+}
+
+// Parse decodes INI data into a nested map. Syntax errors name the offending
+// line, e.g. "ini: line 3: ...".
+func (Parser) Parse(data []byte) (map[string]any, error) {
+	result := make(map[string]any)
+	section := result
+
+	for number, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+
+		switch {
+		case line == "", strings.HasPrefix(line, ";"), strings.HasPrefix(line, "#"):
+			continue
+		case strings.HasPrefix(line, "["):
+			name, ok := strings.CutSuffix(line[1:], "]")
+			name = strings.TrimSpace(name)
+			if !ok || name == "" {
+				return nil, fmt.Errorf("ini: line %d: %w: invalid section header %q", number+1, ErrSyntax, line)
+			}
+
+			section = result
+			for _, part := range strings.Split(name, ".") {
+				section = childMap(section, strings.TrimSpace(part))
+			}
+		default:
+			key, value, ok := strings.Cut(line, "=")
+			key = strings.TrimSpace(key)
+			if !ok || key == "" {
+				return nil, fmt.Errorf("ini: line %d: %w: expected key = value, got %q", number+1, ErrSyntax, line)
+			}
+
+			section[key] = unquote(strings.TrimSpace(value))
+		}
+	}
+
+	return result, nil
+}
+
+// childMap returns the map stored at key in parent, creating it (and
+// replacing any scalar value) when needed.
+func childMap(parent map[string]any, key string) map[string]any {
+	if child, ok := parent[key].(map[string]any); ok {
+		return child
+	}
+
+	child := make(map[string]any)
+	parent[key] = child
+
+	return child
+}
+
+// unquote removes one pair of matching surrounding double or single quotes.
+func unquote(value string) string {
+	if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+		return value[1 : len(value)-1]
+	}
+
+	return value
+}
+
+// Interface assertion: Parser implements contract.FormatParser.
+var _ contract.FormatParser = Parser{}
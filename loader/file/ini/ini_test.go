@@ -0,0 +1,72 @@
+package ini_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/next-trace/scg-config/loader/file"
+	"github.com/next-trace/scg-config/loader/file/ini"
+	"github.com/next-trace/scg-config/provider/viper"
+)
+
+func TestParser_Parse(t *testing.T) {
+	t.Parallel()
+
+	data := `; legacy service config
+name = billing
+# top-level keys precede the first section
+
+[database]
+host = db.internal
+port=5432
+dsn = user=app password="x=y" sslmode=disable
+password = "  padded  "
+
+[database.replica]
+host = replica.internal
+
+[cache]
+; no keys yet
+`
+
+	parsed, err := ini.Parser{}.Parse([]byte(data))
+	require.NoError(t, err)
+	require.Equal(t, map[string]any{
+		"name": "billing",
+		"database": map[string]any{
+			"host":     "db.internal",
+			"port":     "5432",
+			"dsn":      `user=app password="x=y" sslmode=disable`,
+			"password": "  padded  ",
+			"replica":  map[string]any{"host": "replica.internal"},
+		},
+		"cache": map[string]any{},
+	}, parsed)
+}
+
+func TestParser_Parse_SyntaxErrors(t *testing.T) {
+	t.Parallel()
+
+	for _, data := range []string{"[section\nkey = v", "key = v\njust a line", "[]", "= value"} {
+		_, err := ini.Parser{}.Parse([]byte(data))
+		require.ErrorIs(t, err, ini.ErrSyntax, data)
+		require.Contains(t, err.Error(), "line ")
+	}
+}
+
+func TestParser_WithFileLoader(t *testing.T) {
+	t.Parallel()
+	file.RegisterParser(ini.Parser{})
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "app.ini"), []byte("[server]\nport = 8080\n"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "override.cfg"), []byte("[server]\nhost = example.com\n"), 0o600))
+
+	prov := viper.NewConfigProvider()
+	require.NoError(t, file.NewFileLoader(prov).LoadFromDirectory(dir))
+	require.Equal(t, "8080", prov.GetKey("server.port"))
+	require.Equal(t, "example.com", prov.GetKey("server.host"))
+}
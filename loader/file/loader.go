@@ -2,45 +2,151 @@
 package file
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"io/fs"
 	"os"
 	"path/filepath"
+	"reflect"
+	"strconv"
 	"strings"
+	"sync"
 
 	"gopkg.in/yaml.v3"
 
 	"github.com/next-trace/scg-config/configerrors"
 	"github.com/next-trace/scg-config/contract"
+	"github.com/next-trace/scg-config/dotmap"
 	"github.com/next-trace/scg-config/utils"
 )
 
+// SliceMergeStrategy controls how lists are combined when a file is merged
+// over configuration that already defines the same list.
+type SliceMergeStrategy int
+
+// Supported slice merge strategies.
+const (
+	// SliceMergeReplace replaces the existing list with the merged file's list (default).
+	SliceMergeReplace SliceMergeStrategy = iota
+	// SliceMergeAppend concatenates the merged file's list onto the existing list.
+	SliceMergeAppend
+	// SliceMergeUnique concatenates both lists, dropping duplicate elements.
+	SliceMergeUnique
+)
+
 // Loader loads configuration files into the provider provider.
 type Loader struct {
-	provider contract.Provider
+	provider           contract.Provider
+	sliceMergeStrategy SliceMergeStrategy
+	listMergeKeys      map[string]string
+	detectDuplicates   bool
+}
+
+// Option is a functional option for configuring the Loader.
+type Option func(*Loader)
+
+// WithSliceMergeStrategy sets how lists are combined when files are merged
+// (LoadFromDirectory for all but the first file, MergeFromReader, LoadFromStdin).
+// The strategy applies to every list in the load.
+func WithSliceMergeStrategy(strategy SliceMergeStrategy) Option {
+	return func(l *Loader) { l.sliceMergeStrategy = strategy }
+}
+
+// WithListMergeKey merges the list at path (e.g. "plugins") by identity when
+// files are merged: elements are maps, and an incoming element whose idKey
+// value (e.g. "name") matches an existing element is deep-merged into it, while
+// elements with new identities are appended. Elements without idKey are
+// appended as-is. It takes precedence over WithSliceMergeStrategy for that
+// list and may be repeated for several lists.
+func WithListMergeKey(path, idKey string) Option {
+	return func(l *Loader) {
+		if l.listMergeKeys == nil {
+			l.listMergeKeys = make(map[string]string)
+		}
+
+		l.listMergeKeys[strings.ToLower(path)] = idKey
+	}
+}
+
+// WithDuplicateKeyDetection makes every load and merge reject a file that
+// defines the same key twice within one mapping, returning ErrDuplicateKey
+// naming the key and file instead of silently keeping the last value. Keys are
+// compared case-insensitively, since providers fold "Host" and "host" into one
+// key. This catches copy-paste mistakes in hand-edited files.
+func WithDuplicateKeyDetection() Option {
+	return func(l *Loader) { l.detectDuplicates = true }
 }
 
 // NewFileLoader creates a new Loader for the given provider provider.
-func NewFileLoader(p contract.Provider) *Loader {
-	return &Loader{provider: p}
+func NewFileLoader(p contract.Provider, opts ...Option) *Loader {
+	loader := &Loader{provider: p, sliceMergeStrategy: SliceMergeReplace}
+	for _, opt := range opts {
+		opt(loader)
+	}
+
+	return loader
 }
 
-// LoadFromFile loads a single configuration file into the provider.
+// LoadFromFile loads a single configuration file into the provider. A file
+// in a format added via RegisterParser is merged into the provider instead of
+// becoming its config file, so Provider.ReadInConfig does not re-read it.
 func (fl *Loader) LoadFromFile(configFile string) error {
 	provider := fl.provider
 	if provider == nil {
 		return configerrors.ErrBackendProviderHasNoConfig
 	}
 
+	if _, ok := registeredParser(filepath.Ext(configFile)); ok {
+		return fl.mergeConfigFile(configFile)
+	}
+
+	// #nosec G304 -- configFile is an explicit path chosen by the caller; it is only sniffed here
+	// to report extension/content mismatches and record key origins.
+	data, readErr := os.ReadFile(configFile)
+	if readErr == nil {
+		if err := checkFormat(data, filepath.Ext(configFile), configFile); err != nil {
+			return err
+		}
+
+		if err := fl.checkDuplicates(data, filepath.Ext(configFile), configFile); err != nil {
+			return err
+		}
+	}
+
 	provider.SetConfigFile(configFile)
 
 	if err := provider.ReadInConfig(); err != nil {
 		return fmt.Errorf("%w: %w", configerrors.ErrReadConfigFileFailed, err)
 	}
 
+	if readErr == nil {
+		if configMap, err := parseData(data, filepath.Ext(configFile), configFile); err == nil {
+			fl.recordOrigins(configMap, configFile)
+		}
+	}
+
 	return nil
 }
 
+// LoadFileIfExists loads a single configuration file like LoadFromFile, but
+// returns nil when the file does not exist. This is intended for optional
+// overlays such as a developer's local override file. Errors other than
+// not-exist (e.g. permission denied) are still returned.
+func (fl *Loader) LoadFileIfExists(configFile string) error {
+	if _, err := os.Stat(configFile); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+
+		return fmt.Errorf("%w: %w", configerrors.ErrReadConfigFileFailed, err)
+	}
+
+	return fl.LoadFromFile(configFile)
+}
+
 // LoadFromDirectory loads all supported config files from a directory.
 // Files are processed in alphabetical order, with the first file loaded normally
 // and subsequent files merged to preserve nested block structures.
@@ -50,20 +156,9 @@ func (fl *Loader) LoadFromDirectory(dir string) error {
 		return configerrors.ErrBackendProviderHasNoConfig
 	}
 
-	files, err := os.ReadDir(dir)
+	configFiles, err := supportedFiles(dir)
 	if err != nil {
-		return fmt.Errorf("%w: %w", configerrors.ErrFailedReadDirectory, err)
-	}
-
-	// Filter and collect supported config files
-	var configFiles []string
-
-	for _, file := range files {
-		if file.IsDir() || !utils.IsSupportedConfigFile(file.Name()) {
-			continue
-		}
-
-		configFiles = append(configFiles, file.Name())
+		return err
 	}
 
 	if len(configFiles) == 0 {
@@ -77,9 +172,7 @@ func (fl *Loader) LoadFromDirectory(dir string) error {
 
 		if isFirst {
 			// Load the first file normally to establish the base configuration
-			provider.SetConfigFile(path)
-
-			if err := provider.ReadInConfig(); err != nil {
+			if err := fl.LoadFromFile(path); err != nil {
 				return fmt.Errorf("failed to load initial config file %s: %w", path, err)
 			}
 
@@ -95,6 +188,127 @@ func (fl *Loader) LoadFromDirectory(dir string) error {
 	return nil
 }
 
+// LoadFromDirectoryStrict behaves like LoadFromDirectory but returns
+// ErrNoConfigFilesFound when dir exists and holds no supported config file,
+// catching a volume mounted at the wrong path. A missing directory still
+// returns ErrFailedReadDirectory.
+func (fl *Loader) LoadFromDirectoryStrict(dir string) error {
+	if fl.provider == nil {
+		return configerrors.ErrBackendProviderHasNoConfig
+	}
+
+	configFiles, err := supportedFiles(dir)
+	if err != nil {
+		return err
+	}
+
+	if len(configFiles) == 0 {
+		return fmt.Errorf("%w: %s", configerrors.ErrNoConfigFilesFound, dir)
+	}
+
+	return fl.LoadFromDirectory(dir)
+}
+
+// supportedFiles returns the names of the supported config files in dir, in
+// alphabetical order.
+func supportedFiles(dir string) ([]string, error) {
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", configerrors.ErrFailedReadDirectory, err)
+	}
+
+	var configFiles []string
+
+	for _, file := range files {
+		if file.IsDir() || !utils.IsSupportedConfigFile(file.Name()) {
+			continue
+		}
+
+		configFiles = append(configFiles, file.Name())
+	}
+
+	return configFiles, nil
+}
+
+// LoadFromKeyPerFileDir loads a directory in which every file holds a single
+// value, as Kubernetes mounts ConfigMaps and Secrets: the file name is the key
+// (dots denote nesting, e.g. "db.host") and the content, with trailing
+// newlines removed, is the value. Hidden entries such as the "..data" symlink
+// and timestamped directories created by Kubernetes are ignored, and symlinked
+// files are followed. Values are merged over the existing configuration.
+func (fl *Loader) LoadFromKeyPerFileDir(dir string) error {
+	if fl.provider == nil {
+		return configerrors.ErrBackendProviderHasNoConfig
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return fmt.Errorf("%w: %w", configerrors.ErrFailedReadDirectory, err)
+	}
+
+	configMap := make(map[string]interface{})
+	sources := make(map[string]string)
+
+	for _, entry := range entries {
+		if strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+
+		path := filepath.Join(dir, entry.Name())
+
+		// Stat follows symlinks, which is how Kubernetes exposes each key.
+		info, err := os.Stat(path)
+		if err != nil {
+			return fmt.Errorf("%w: %w", configerrors.ErrReadConfigFileFailed, err)
+		}
+
+		if info.IsDir() {
+			continue
+		}
+
+		// #nosec G304 -- path is an entry of the directory explicitly chosen by the caller.
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("%w: %w", configerrors.ErrReadConfigFileFailed, err)
+		}
+
+		key := strings.ToLower(entry.Name())
+		setNested(configMap, strings.Split(key, "."), strings.TrimRight(string(data), "\r\n"))
+		sources[key] = path
+	}
+
+	if len(configMap) == 0 {
+		return nil
+	}
+
+	if err := fl.provider.MergeConfigMap(configMap); err != nil {
+		return fmt.Errorf("failed to merge configuration map: %w", err)
+	}
+
+	if tracker, ok := fl.provider.(contract.OriginTracker); ok {
+		for key, path := range sources {
+			tracker.RecordOrigin(key, contract.OriginFilePrefix+path)
+		}
+	}
+
+	return nil
+}
+
+// setNested stores value in m under the nested path, creating maps as needed.
+func setNested(m map[string]interface{}, path []string, value interface{}) {
+	for _, part := range path[:len(path)-1] {
+		child, ok := m[part].(map[string]interface{})
+		if !ok {
+			child = make(map[string]interface{})
+			m[part] = child
+		}
+
+		m = child
+	}
+
+	m[path[len(path)-1]] = value
+}
+
 // mergeConfigFile merges a configuration file into the existing provider configuration.
 // This method parses the file to a generic map and merges via the Provider interface,
 // keeping this loader decoupled from any specific backend implementation.
@@ -107,25 +321,609 @@ func (fl *Loader) mergeConfigFile(configFile string) error {
 		return fmt.Errorf("failed to read config file for merging: %w", err)
 	}
 
+	if err := fl.mergeData(data, filepath.Ext(configFile), configFile); err != nil {
+		return err
+	}
+
+	if tracker, ok := fl.provider.(contract.ConfigFileTracker); ok {
+		tracker.RecordConfigFile(configFile)
+	}
+
+	return nil
+}
+
+// LoadFromEncryptedFile reads the encrypted file at path, passes its content
+// to decrypt and merges the returned plaintext, parsed according to format
+// (e.g. "yaml"; the extension of path when empty), into the provider. The
+// cryptography stays with the caller. A failure of decrypt is returned wrapped
+// in ErrDecryptFailed, so it can be told apart from a parse error of the
+// plaintext, which is reported like one from MergeFromReader.
+func (fl *Loader) LoadFromEncryptedFile(path string, decrypt func([]byte) ([]byte, error), format string) error {
+	if fl.provider == nil {
+		return configerrors.ErrBackendProviderHasNoConfig
+	}
+
+	// #nosec G304 -- path is an explicit encrypted config file chosen by the caller.
+	ciphertext, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("%w: %w", configerrors.ErrReadConfigFileFailed, err)
+	}
+
+	plaintext, err := decrypt(ciphertext)
+	if err != nil {
+		return fmt.Errorf("%w: %s: %w", configerrors.ErrDecryptFailed, path, err)
+	}
+
+	if format == "" {
+		format = filepath.Ext(path)
+	}
+
+	if err := fl.mergeData(plaintext, format, path); err != nil {
+		return err
+	}
+
+	if tracker, ok := fl.provider.(contract.ConfigFileTracker); ok {
+		tracker.RecordConfigFile(path)
+	}
+
+	return nil
+}
+
+// MergeFromReader reads all configuration data from r, parses it according to
+// format and merges the result into the provider. The format is a file
+// extension with or without the leading dot (e.g. "yaml", ".json").
+func (fl *Loader) MergeFromReader(r io.Reader, format string) error {
+	return fl.mergeReader(r, format, "reader")
+}
+
+// LoadFromStdin reads configuration from os.Stdin in the given format and
+// merges it into the provider. The format must be explicit since stdin has no
+// file extension. This call blocks until stdin is closed, so it should not be
+// used when stdin is an interactive terminal.
+func (fl *Loader) LoadFromStdin(format string) error {
+	return fl.mergeReader(os.Stdin, format, "stdin")
+}
+
+// mergeReader reads all data from r and merges it into the provider. The
+// source names the origin of the data in parse errors.
+func (fl *Loader) mergeReader(r io.Reader, format, source string) error {
+	if fl.provider == nil {
+		return configerrors.ErrBackendProviderHasNoConfig
+	}
+
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return fmt.Errorf("failed to read config data for merging: %w", err)
+	}
+
+	return fl.mergeData(data, format, source)
+}
+
+// mergeData parses raw configuration data for the given format and merges it
+// into the provider. The source names the origin of the data in parse errors.
+func (fl *Loader) mergeData(data []byte, format, source string) error {
+	if err := fl.checkDuplicates(data, format, source); err != nil {
+		return err
+	}
+
+	configMap, err := parseData(data, format, source)
+	if err != nil {
+		return err
+	}
+
+	if fl.sliceMergeStrategy != SliceMergeReplace || len(fl.listMergeKeys) > 0 {
+		configMap = fl.combineSlices(fl.provider.AllSettings(), configMap, "")
+	}
+
+	if err := fl.provider.MergeConfigMap(configMap); err != nil {
+		return fmt.Errorf("failed to merge configuration map: %w", err)
+	}
+
+	fl.recordOrigins(configMap, source)
+
+	return nil
+}
+
+// combineSlices returns a copy of incoming in which every list that also exists
+// in existing at the same path is combined with it according to the loader's
+// list merge keys and slice merge strategy. prefix is the dotted path of
+// incoming within the whole configuration.
+func (fl *Loader) combineSlices(existing, incoming map[string]interface{}, prefix string) map[string]interface{} {
+	result := make(map[string]interface{}, len(incoming))
+
+	for key, value := range incoming {
+		current := dotmap.Resolve(existing, key)
+		path := strings.ToLower(key)
+		if prefix != "" {
+			path = prefix + "." + path
+		}
+
+		switch typed := value.(type) {
+		case map[string]interface{}:
+			if currentMap, ok := current.(map[string]interface{}); ok {
+				result[key] = fl.combineSlices(currentMap, typed, path)
+
+				continue
+			}
+		case []interface{}:
+			currentSlice, ok := current.([]interface{})
+			if !ok {
+				break
+			}
+
+			if idKey, keyed := fl.listMergeKeys[path]; keyed {
+				result[key] = mergeByKey(currentSlice, typed, idKey)
+
+				continue
+			}
+
+			if fl.sliceMergeStrategy != SliceMergeReplace {
+				result[key] = appendSlice(currentSlice, typed, fl.sliceMergeStrategy == SliceMergeUnique)
+
+				continue
+			}
+		}
+
+		result[key] = value
+	}
+
+	return result
+}
+
+// appendSlice concatenates incoming onto existing, skipping elements already
+// present when unique is set.
+func appendSlice(existing, incoming []interface{}, unique bool) []interface{} {
+	combined := make([]interface{}, 0, len(existing)+len(incoming))
+
+	for _, elem := range append(append([]interface{}{}, existing...), incoming...) {
+		if unique && containsValue(combined, elem) {
+			continue
+		}
+
+		combined = append(combined, elem)
+	}
+
+	return combined
+}
+
+// mergeByKey merges incoming into existing by identity: map elements whose
+// idKey values match are deep-merged, with incoming values winning, and all
+// other elements are appended in order.
+func mergeByKey(existing, incoming []interface{}, idKey string) []interface{} {
+	merged := append([]interface{}{}, existing...)
+
+	for _, elem := range incoming {
+		elemMap, isMap := elem.(map[string]interface{})
+		if !isMap {
+			merged = append(merged, elem)
+
+			continue
+		}
+
+		index, current := indexByKey(merged, elemMap, idKey)
+		if index < 0 {
+			merged = append(merged, elem)
+
+			continue
+		}
+
+		merged[index] = mergeMaps(current, elemMap)
+	}
+
+	return merged
+}
+
+// indexByKey returns the index and value of the map element in values whose
+// idKey value equals that of elem, or -1 when elem has no identity or no
+// element matches.
+func indexByKey(values []interface{}, elem map[string]interface{}, idKey string) (int, map[string]interface{}) {
+	id, ok := elem[idKey]
+	if !ok {
+		return -1, nil
+	}
+
+	for i, value := range values {
+		valueMap, isMap := value.(map[string]interface{})
+		if isMap && reflect.DeepEqual(valueMap[idKey], id) {
+			return i, valueMap
+		}
+	}
+
+	return -1, nil
+}
+
+// mergeMaps returns a deep merge of over onto base; nested maps are merged
+// recursively and any other value in over replaces the one in base.
+func mergeMaps(base, over map[string]interface{}) map[string]interface{} {
+	merged := make(map[string]interface{}, len(base)+len(over))
+	for key, value := range base {
+		merged[key] = value
+	}
+
+	for key, value := range over {
+		baseMap, baseIsMap := merged[key].(map[string]interface{})
+		overMap, overIsMap := value.(map[string]interface{})
+		if baseIsMap && overIsMap {
+			merged[key] = mergeMaps(baseMap, overMap)
+
+			continue
+		}
+
+		merged[key] = value
+	}
+
+	return merged
+}
+
+// containsValue reports whether values contains an element deeply equal to value.
+func containsValue(values []interface{}, value interface{}) bool {
+	for _, elem := range values {
+		if reflect.DeepEqual(elem, value) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// DescribeFile maps the dotted (lower-cased) keys of the YAML file at path to
+// the comment block immediately preceding each key, e.g. for a
+// --describe-config command. Multi-line comment blocks are joined with single
+// spaces and keys without a comment are omitted. The file is only read, not
+// loaded into the provider. JSON has no comments, so other formats return
+// ErrUnsupportedExtension.
+func (fl *Loader) DescribeFile(path string) (map[string]string, error) {
+	ext := strings.ToLower(filepath.Ext(path))
+	if ext != contract.ExtYAML && ext != contract.ExtYML {
+		return nil, fmt.Errorf("%w: %q", configerrors.ErrUnsupportedExtension, ext)
+	}
+
+	// #nosec G304 -- path is an explicit file chosen by the caller.
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", configerrors.ErrReadConfigFileFailed, err)
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, parseError(path, data, err)
+	}
+
+	descriptions := make(map[string]string)
+	for _, root := range doc.Content {
+		describeNode(descriptions, "", root)
+	}
+
+	return descriptions, nil
+}
+
+// describeNode records the head comments of the keys of a YAML mapping node,
+// recursing into nested mappings.
+func describeNode(descriptions map[string]string, prefix string, node *yaml.Node) {
+	if node.Kind != yaml.MappingNode {
+		return
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		keyNode, valueNode := node.Content[i], node.Content[i+1]
+
+		key := joinKey(prefix, strings.ToLower(keyNode.Value))
+
+		if comment := commentText(keyNode.HeadComment); comment != "" {
+			descriptions[key] = comment
+		}
+
+		describeNode(descriptions, key, valueNode)
+	}
+}
+
+// commentText strips the comment markers from a YAML comment block and joins
+// its lines with single spaces.
+func commentText(comment string) string {
+	var lines []string
+
+	for _, line := range strings.Split(comment, "\n") {
+		line = strings.TrimSpace(strings.TrimLeft(strings.TrimSpace(line), "#"))
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+
+	return strings.Join(lines, " ")
+}
+
+// checkDuplicates reports ErrDuplicateKey for the first key defined twice in
+// data when duplicate detection is enabled. Data that does not parse is left
+// to parseData to report.
+func (fl *Loader) checkDuplicates(data []byte, format, source string) error {
+	if !fl.detectDuplicates {
+		return nil
+	}
+
+	var key string
+
+	switch normalizeExt(format) {
+	case contract.ExtYAML, contract.ExtYML:
+		var doc yaml.Node
+		if err := yaml.Unmarshal(data, &doc); err != nil {
+			return nil
+		}
+
+		for _, root := range doc.Content {
+			if key = duplicateYAMLKey(root, ""); key != "" {
+				break
+			}
+		}
+	case contract.ExtJSON:
+		key, _ = duplicateJSONKey(json.NewDecoder(bytes.NewReader(data)), "")
+	}
+
+	if key == "" {
+		return nil
+	}
+
+	return fmt.Errorf("%w: %q in %s", configerrors.ErrDuplicateKey, key, source)
+}
+
+// duplicateYAMLKey returns the dotted path of the first duplicated key within
+// node, or "" when all keys are unique.
+func duplicateYAMLKey(node *yaml.Node, prefix string) string {
+	switch node.Kind {
+	case yaml.MappingNode:
+		seen := make(map[string]bool, len(node.Content)/2)
+
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			path := joinKey(prefix, node.Content[i].Value)
+
+			name := strings.ToLower(node.Content[i].Value)
+			if seen[name] {
+				return path
+			}
+
+			seen[name] = true
+
+			if key := duplicateYAMLKey(node.Content[i+1], path); key != "" {
+				return key
+			}
+		}
+	case yaml.SequenceNode:
+		for i, child := range node.Content {
+			if key := duplicateYAMLKey(child, joinKey(prefix, strconv.Itoa(i))); key != "" {
+				return key
+			}
+		}
+	}
+
+	return ""
+}
+
+// duplicateJSONKey reads the next JSON value from dec and returns the dotted
+// path of the first duplicated key within it, or "" when all keys are unique.
+// encoding/json keeps the last of duplicated keys, so the token stream is
+// inspected instead.
+func duplicateJSONKey(dec *json.Decoder, prefix string) (string, error) {
+	token, err := dec.Token()
+	if err != nil {
+		return "", err
+	}
+
+	delim, ok := token.(json.Delim)
+	if !ok {
+		return "", nil
+	}
+
+	switch delim {
+	case '{':
+		seen := make(map[string]bool)
+
+		for dec.More() {
+			token, err := dec.Token()
+			if err != nil {
+				return "", err
+			}
+
+			name, _ := token.(string)
+			path := joinKey(prefix, name)
+
+			if seen[strings.ToLower(name)] {
+				return path, nil
+			}
+
+			seen[strings.ToLower(name)] = true
+
+			if key, err := duplicateJSONKey(dec, path); key != "" || err != nil {
+				return key, err
+			}
+		}
+	case '[':
+		for i := 0; dec.More(); i++ {
+			if key, err := duplicateJSONKey(dec, joinKey(prefix, strconv.Itoa(i))); key != "" || err != nil {
+				return key, err
+			}
+		}
+	}
+
+	// Consume the closing delimiter.
+	_, err = dec.Token()
+
+	return "", err
+}
+
+// joinKey appends key to the dotted prefix.
+func joinKey(prefix, key string) string {
+	if prefix == "" {
+		return key
+	}
+
+	return prefix + "." + key
+}
+
+// parseData parses raw configuration data for the given format into a map.
+// The source names the origin of the data in parse errors.
+func parseData(data []byte, format, source string) (map[string]interface{}, error) {
 	var configMap map[string]interface{}
-	ext := strings.ToLower(filepath.Ext(configFile))
+	ext := normalizeExt(format)
+	if err := checkFormat(data, ext, source); err != nil {
+		return nil, err
+	}
+
 	switch ext {
 	case ".yaml", ".yml":
 		if err := yaml.Unmarshal(data, &configMap); err != nil {
-			return fmt.Errorf("failed to parse YAML config for merging: %w", err)
+			return nil, parseError(source, data, err)
 		}
 	case ".json":
-		if err := json.Unmarshal(data, &configMap); err != nil {
-			return fmt.Errorf("failed to parse JSON config for merging: %w", err)
+		decoded, err := utils.UnmarshalJSON(data)
+		if err != nil {
+			return nil, parseError(source, data, err)
 		}
+
+		configMap = decoded
 	default:
-		return fmt.Errorf("unsupported config file extension %q", ext)
+		parser, ok := registeredParser(ext)
+		if !ok {
+			return nil, fmt.Errorf("%w: %q", configerrors.ErrUnsupportedExtension, ext)
+		}
+
+		parsed, err := parser.Parse(data)
+		if err != nil {
+			return nil, parseError(source, data, err)
+		}
+
+		configMap = parsed
 	}
 
-	if err := fl.provider.MergeConfigMap(configMap); err != nil {
-		return fmt.Errorf("failed to merge configuration map: %w", err)
+	return configMap, nil
+}
+
+// parsers holds the format parsers added via RegisterParser, keyed by
+// normalized extension.
+var (
+	parsersMu sync.RWMutex
+	parsers   = make(map[string]contract.FormatParser)
+)
+
+// RegisterParser adds the file formats of p to every Loader: files with its
+// extensions are parsed by p when loaded or merged, and LoadFromDirectory (via
+// utils.IsSupportedConfigFile) picks them up. YAML and JSON always use the
+// built-in parsers. Registering another parser for an extension replaces the
+// previous one.
+func RegisterParser(p contract.FormatParser) {
+	parsersMu.Lock()
+	defer parsersMu.Unlock()
+
+	for _, ext := range p.Extensions() {
+		ext = normalizeExt(ext)
+		if ext == contract.ExtYAML || ext == contract.ExtYML || ext == contract.ExtJSON {
+			continue
+		}
+
+		parsers[ext] = p
+		utils.RegisterConfigExtension(ext)
 	}
-	return nil
+}
+
+// registeredParser returns the parser registered for ext, if any.
+//
+//nolint:ireturn // parsers are pluggable implementations of contract.FormatParser
+func registeredParser(ext string) (contract.FormatParser, bool) {
+	parsersMu.RLock()
+	defer parsersMu.RUnlock()
+
+	parser, ok := parsers[normalizeExt(ext)]
+
+	return parser, ok
+}
+
+// normalizeExt lower-cases a format or extension and ensures a leading dot,
+// so "YAML", "yaml" and ".yaml" all become ".yaml".
+func normalizeExt(format string) string {
+	return "." + strings.TrimPrefix(strings.ToLower(format), ".")
+}
+
+// recordOrigins tags every leaf key of configMap with source as its origin when
+// the provider supports origin tracking.
+func (fl *Loader) recordOrigins(configMap map[string]interface{}, source string) {
+	tracker, ok := fl.provider.(contract.OriginTracker)
+	if !ok {
+		return
+	}
+
+	for key := range dotmap.Flatten(configMap) {
+		tracker.RecordOrigin(key, contract.OriginFilePrefix+source)
+	}
+}
+
+// checkFormat sniffs data and reports ErrFormatMismatch when a JSON extension is
+// used for content that is clearly not JSON. JSON content in a YAML file is
+// accepted because YAML is a superset of JSON.
+func checkFormat(data []byte, ext, source string) error {
+	if strings.ToLower(ext) != contract.ExtJSON {
+		return nil
+	}
+
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) == 0 || trimmed[0] == '{' || trimmed[0] == '[' {
+		return nil
+	}
+
+	return fmt.Errorf("%w: %s has a %s extension but does not look like JSON (YAML content?)",
+		configerrors.ErrFormatMismatch, source, ext)
+}
+
+// parseError wraps a YAML/JSON parse error with its source and, where the
+// underlying library provides it, the line (and column for JSON) of the failure,
+// e.g. "config: parse error in /etc/app/db.yaml:12: ...".
+func parseError(source string, data []byte, err error) error {
+	location := source
+
+	var syntaxErr *json.SyntaxError
+
+	var typeErr *json.UnmarshalTypeError
+
+	switch {
+	case errors.As(err, &syntaxErr):
+		location += lineCol(data, syntaxErr.Offset)
+	case errors.As(err, &typeErr):
+		location += lineCol(data, typeErr.Offset)
+	default:
+		if line := yamlErrorLine(err.Error()); line > 0 {
+			location += fmt.Sprintf(":%d", line)
+		}
+	}
+
+	return fmt.Errorf("config: parse error in %s: %w", location, err)
+}
+
+// lineCol converts a byte offset within data into a ":line:column" suffix.
+func lineCol(data []byte, offset int64) string {
+	if offset < 0 || offset > int64(len(data)) {
+		return ""
+	}
+
+	prefix := data[:offset]
+	line := bytes.Count(prefix, []byte("\n")) + 1
+	col := len(prefix) - bytes.LastIndexByte(prefix, '\n')
+
+	return fmt.Sprintf(":%d:%d", line, col)
+}
+
+// yamlErrorLine extracts the line number from a yaml.v3 error message of the
+// form "yaml: line 12: ...". It returns 0 when no line number is present.
+func yamlErrorLine(msg string) int {
+	_, rest, found := strings.Cut(msg, "line ")
+	if !found {
+		return 0
+	}
+
+	digits, _, _ := strings.Cut(rest, ":")
+
+	line, err := strconv.Atoi(digits)
+	if err != nil {
+		return 0
+	}
+
+	return line
 }
 
 // GetProvider returns the Provider associated with the Loader.
@@ -1,8 +1,11 @@
 package file_test
 
 import (
+	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -12,6 +15,7 @@ import (
 	"github.com/next-trace/scg-config/contract"
 	"github.com/next-trace/scg-config/loader/file"
 	"github.com/next-trace/scg-config/provider/viper"
+	"github.com/next-trace/scg-config/utils"
 )
 
 func TestFileLoader_LoadFromFile_AllSupportedExtensions(t *testing.T) {
@@ -131,11 +135,13 @@ type fakeProvider struct{}
 
 func (f *fakeProvider) ReadInConfig() error                         { return nil }
 func (f *fakeProvider) AllSettings() map[string]interface{}         { return map[string]interface{}{} }
+func (f *fakeProvider) Snapshot() map[string]interface{}            { return map[string]interface{}{} }
 func (f *fakeProvider) GetKey(string) any                           { return nil }
 func (f *fakeProvider) Set(string, any)                             {}
 func (f *fakeProvider) IsSet(string) bool                           { return false }
 func (f *fakeProvider) Provider() any                               { return nil }
 func (f *fakeProvider) SetConfigFile(string)                        {}
+func (f *fakeProvider) ConfigFilesUsed() []string                   { return nil }
 func (f *fakeProvider) MergeConfigMap(map[string]interface{}) error { return assertErr }
 
 type assertError string
@@ -199,3 +205,480 @@ func TestFileLoader_LoadFromDirectory_NoProvider_Error(t *testing.T) {
 	require.Error(t, err)
 	require.ErrorIs(t, err, configerrors.ErrBackendProviderHasNoConfig)
 }
+
+func TestFileLoader_LoadFileIfExists_MissingFile_NoError(t *testing.T) {
+	t.Parallel()
+	p := viper.NewConfigProvider()
+	ldr := file.NewFileLoader(p)
+	require.NoError(t, ldr.LoadFileIfExists(filepath.Join(t.TempDir(), "local.yaml")))
+	require.Nil(t, p.GetKey("app.name"))
+}
+
+func TestFileLoader_LoadFileIfExists_ExistingFile_Loads(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "local.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("app:\n  name: local"), 0o600))
+
+	p := viper.NewConfigProvider()
+	ldr := file.NewFileLoader(p)
+	require.NoError(t, ldr.LoadFileIfExists(path))
+	require.Equal(t, "local", p.GetKey("app.name"))
+}
+
+func TestFileLoader_LoadFileIfExists_StatError_Propagates(t *testing.T) {
+	t.Parallel()
+	// A path component that is a regular file yields ENOTDIR, not ENOENT.
+	dir := t.TempDir()
+	notDir := filepath.Join(dir, "plain")
+	require.NoError(t, os.WriteFile(notDir, []byte("x"), 0o600))
+
+	ldr := file.NewFileLoader(viper.NewConfigProvider())
+	err := ldr.LoadFileIfExists(filepath.Join(notDir, "local.yaml"))
+	require.Error(t, err)
+	require.ErrorIs(t, err, configerrors.ErrReadConfigFileFailed)
+}
+
+func TestFileLoader_MergeFromReader(t *testing.T) {
+	t.Parallel()
+	p := viper.NewConfigProvider()
+	ldr := file.NewFileLoader(p)
+
+	require.NoError(t, ldr.MergeFromReader(strings.NewReader("app:\n  name: piped"), "yaml"))
+	require.NoError(t, ldr.MergeFromReader(strings.NewReader(`{"app": {"port": 8080}}`), ".JSON"))
+	require.Equal(t, "piped", p.GetKey("app.name"))
+	require.EqualValues(t, 8080, p.GetKey("app.port"))
+}
+
+func TestFileLoader_MergeFromReader_UnsupportedFormat(t *testing.T) {
+	t.Parallel()
+	ldr := file.NewFileLoader(viper.NewConfigProvider())
+	err := ldr.MergeFromReader(strings.NewReader("a = 1"), "toml")
+	require.ErrorIs(t, err, configerrors.ErrUnsupportedExtension)
+}
+
+func TestFileLoader_LoadFromStdin(t *testing.T) {
+	// Note: Cannot use t.Parallel() because this test replaces os.Stdin
+	path := filepath.Join(t.TempDir(), "stdin.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"app": {"name": "stdin"}}`), 0o600))
+
+	stdin, err := os.Open(path)
+	require.NoError(t, err)
+	defer func() { _ = stdin.Close() }()
+
+	orig := os.Stdin
+	os.Stdin = stdin
+	defer func() { os.Stdin = orig }()
+
+	p := viper.NewConfigProvider()
+	require.NoError(t, file.NewFileLoader(p).LoadFromStdin("json"))
+	require.Equal(t, "stdin", p.GetKey("app.name"))
+}
+
+func TestLoadFromDirectory_SkipsUnsupportedExtension(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a.yaml"), []byte("a: 1"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "b.toml"), []byte("b = 2"), 0o600))
+
+	p := viper.NewConfigProvider()
+	require.NoError(t, file.NewFileLoader(p).LoadFromDirectory(dir))
+	require.Nil(t, p.GetKey("b"))
+}
+
+func TestLoadFromDirectory_ParseError_IncludesLocation(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name    string
+		file    string
+		content string
+		want    string
+	}{
+		{"yaml", "b.yaml", "ok: 1\nbad: [unclosed\n", "b.yaml:"},
+		{"json", "b.json", "{\n  \"a\": 1,\n  \"b\": }\n", "b.json:3:"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			dir := t.TempDir()
+			require.NoError(t, os.WriteFile(filepath.Join(dir, "a.yaml"), []byte("a: 1"), 0o600))
+			bad := filepath.Join(dir, tc.file)
+			require.NoError(t, os.WriteFile(bad, []byte(tc.content), 0o600))
+
+			err := file.NewFileLoader(viper.NewConfigProvider()).LoadFromDirectory(dir)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), "config: parse error in "+bad)
+			require.Contains(t, err.Error(), tc.want)
+		})
+	}
+}
+
+func TestFileLoader_JSONContentInYAMLFile_Loads(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`{"app": {"name": "json-in-yaml"}}`), 0o600))
+
+	p := viper.NewConfigProvider()
+	require.NoError(t, file.NewFileLoader(p).LoadFromFile(path))
+	require.Equal(t, "json-in-yaml", p.GetKey("app.name"))
+
+	// The merge path accepts it as well.
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "other.yaml"), []byte(`{"db": {"port": 5432}}`), 0o600))
+	p2 := viper.NewConfigProvider()
+	require.NoError(t, file.NewFileLoader(p2).LoadFromDirectory(dir))
+	require.EqualValues(t, 5432, p2.GetKey("db.port"))
+}
+
+func TestFileLoader_YAMLContentInJSONFile_FormatMismatch(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.json")
+	require.NoError(t, os.WriteFile(path, []byte("app:\n  name: yaml\n"), 0o600))
+
+	err := file.NewFileLoader(viper.NewConfigProvider()).LoadFromFile(path)
+	require.ErrorIs(t, err, configerrors.ErrFormatMismatch)
+	require.Contains(t, err.Error(), path)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a.yaml"), []byte("a: 1"), 0o600))
+	err = file.NewFileLoader(viper.NewConfigProvider()).LoadFromDirectory(dir)
+	require.ErrorIs(t, err, configerrors.ErrFormatMismatch)
+}
+
+func TestLoadFromDirectory_SliceMergeStrategies(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name     string
+		strategy file.SliceMergeStrategy
+		want     []any
+	}{
+		{"replace", file.SliceMergeReplace, []any{"auth", "metrics"}},
+		{"append", file.SliceMergeAppend, []any{"cache", "auth", "auth", "metrics"}},
+		{"unique", file.SliceMergeUnique, []any{"cache", "auth", "metrics"}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			dir := t.TempDir()
+			require.NoError(t, os.WriteFile(filepath.Join(dir, "a.yaml"),
+				[]byte("plugins:\n  - cache\n  - auth\napp:\n  tags: [a]\n"), 0o600))
+			require.NoError(t, os.WriteFile(filepath.Join(dir, "b.json"),
+				[]byte(`{"plugins": ["auth", "metrics"], "app": {"tags": ["a", "b"]}}`), 0o600))
+
+			p := viper.NewConfigProvider()
+			ldr := file.NewFileLoader(p, file.WithSliceMergeStrategy(tc.strategy))
+			require.NoError(t, ldr.LoadFromDirectory(dir))
+			require.Equal(t, tc.want, p.GetKey("plugins"))
+		})
+	}
+}
+
+func TestMergeFromReader_SliceMergeUnique_Nested(t *testing.T) {
+	t.Parallel()
+	p := viper.NewConfigProvider()
+	ldr := file.NewFileLoader(p, file.WithSliceMergeStrategy(file.SliceMergeUnique))
+
+	require.NoError(t, ldr.MergeFromReader(strings.NewReader("app:\n  tags: [a, b]\n  name: x\n"), "yaml"))
+	require.NoError(t, ldr.MergeFromReader(strings.NewReader("app:\n  tags: [b, c]\n"), "yaml"))
+	require.Equal(t, []any{"a", "b", "c"}, p.GetKey("app.tags"))
+	require.Equal(t, "x", p.GetKey("app.name"))
+}
+
+func TestFileLoader_LoadFromKeyPerFileDir(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+
+	// Kubernetes layout: keys are symlinks into ..data, which points at a
+	// hidden timestamped directory holding the actual files.
+	versionDir := filepath.Join(dir, "..2024_01_01_00_00_00.000000001")
+	require.NoError(t, os.Mkdir(versionDir, 0o700))
+	require.NoError(t, os.WriteFile(filepath.Join(versionDir, "db.host"), []byte("db.internal\n"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(versionDir, "LOG_LEVEL"), []byte("debug\r\n"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(versionDir, "banner"), []byte("  hello world\n\n"), 0o600))
+	require.NoError(t, os.Symlink(filepath.Base(versionDir), filepath.Join(dir, "..data")))
+
+	for _, key := range []string{"db.host", "LOG_LEVEL", "banner"} {
+		require.NoError(t, os.Symlink(filepath.Join("..data", key), filepath.Join(dir, key)))
+	}
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".hidden"), []byte("nope"), 0o600))
+
+	prov := viper.NewConfigProvider()
+	prov.Set("db.port", 5432)
+	require.NoError(t, file.NewFileLoader(prov).LoadFromKeyPerFileDir(dir))
+
+	all := prov.AllSettings()
+	require.Equal(t, "db.internal", prov.GetKey("db.host"))
+	require.Equal(t, 5432, prov.GetKey("db.port"))
+	require.Equal(t, "debug", all["log_level"])
+	require.Equal(t, "  hello world", all["banner"])
+	require.NotContains(t, all, ".hidden")
+	require.NotContains(t, all, "..data")
+
+	origin, ok := prov.Origin("db.host")
+	require.True(t, ok)
+	require.Equal(t, contract.OriginFilePrefix+filepath.Join(dir, "db.host"), origin)
+
+	require.ErrorIs(t, file.NewFileLoader(prov).LoadFromKeyPerFileDir(filepath.Join(dir, "missing")),
+		configerrors.ErrFailedReadDirectory)
+}
+
+func TestLoadFromDirectory_ListMergeKey(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a.yaml"), []byte(`plugins:
+  - name: auth
+    enabled: true
+    options:
+      ttl: 30
+  - name: cache
+    enabled: false
+`), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "b.yaml"), []byte(`plugins:
+  - name: auth
+    options:
+      issuer: acme
+  - name: metrics
+    enabled: true
+`), 0o600))
+
+	p := viper.NewConfigProvider()
+	ldr := file.NewFileLoader(p, file.WithListMergeKey("plugins", "name"))
+	require.NoError(t, ldr.LoadFromDirectory(dir))
+
+	require.Equal(t, []any{
+		map[string]any{"name": "auth", "enabled": true, "options": map[string]any{"ttl": 30, "issuer": "acme"}},
+		map[string]any{"name": "cache", "enabled": false},
+		map[string]any{"name": "metrics", "enabled": true},
+	}, p.GetKey("plugins"))
+}
+
+func TestLoadFromDirectory_ConfigFilesUsed(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "b.json"), []byte(`{"b": 1}`), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a.yaml"), []byte("a: 1\n"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "c.yml"), []byte("c: 1\n"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("ignored"), 0o600))
+
+	p := viper.NewConfigProvider()
+	require.Empty(t, p.ConfigFilesUsed())
+
+	require.NoError(t, file.NewFileLoader(p).LoadFromDirectory(dir))
+	require.Equal(t, []string{
+		filepath.Join(dir, "a.yaml"),
+		filepath.Join(dir, "b.json"),
+		filepath.Join(dir, "c.yml"),
+	}, p.ConfigFilesUsed())
+
+	// Loading a single file starts over.
+	require.NoError(t, file.NewFileLoader(p).LoadFromFile(filepath.Join(dir, "c.yml")))
+	require.Equal(t, []string{filepath.Join(dir, "c.yml")}, p.ConfigFilesUsed())
+}
+
+func TestFileLoader_LoadFromEncryptedFile(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+
+	// XOR stands in for real encryption; the loader only sees bytes.
+	xor := func(data []byte) []byte {
+		out := make([]byte, len(data))
+		for i, b := range data {
+			out[i] = b ^ 0x5a
+		}
+
+		return out
+	}
+	decrypt := func(data []byte) ([]byte, error) { return xor(data), nil }
+
+	path := filepath.Join(dir, "secrets.yaml.enc")
+	require.NoError(t, os.WriteFile(path, xor([]byte("db:\n  password: s3cret\n")), 0o600))
+
+	p := viper.NewConfigProvider()
+	ldr := file.NewFileLoader(p)
+	require.NoError(t, ldr.LoadFromEncryptedFile(path, decrypt, "yaml"))
+	require.Equal(t, "s3cret", p.GetKey("db.password"))
+	require.Equal(t, []string{path}, p.ConfigFilesUsed())
+
+	decryptErr := errors.New("bad key")
+	err := ldr.LoadFromEncryptedFile(path, func([]byte) ([]byte, error) { return nil, decryptErr }, "yaml")
+	require.ErrorIs(t, err, configerrors.ErrDecryptFailed)
+	require.ErrorIs(t, err, decryptErr)
+
+	broken := filepath.Join(dir, "broken.enc")
+	require.NoError(t, os.WriteFile(broken, xor([]byte("db: [unclosed\n")), 0o600))
+	err = ldr.LoadFromEncryptedFile(broken, decrypt, "yaml")
+	require.Error(t, err)
+	require.NotErrorIs(t, err, configerrors.ErrDecryptFailed)
+
+	err = ldr.LoadFromEncryptedFile(filepath.Join(dir, "missing.enc"), decrypt, "yaml")
+	require.ErrorIs(t, err, configerrors.ErrReadConfigFileFailed)
+}
+
+func TestFileLoader_LoadFromDirectoryStrict(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "README.md"), []byte("not config"), 0o600))
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "nested.yaml"), 0o700))
+
+	p := viper.NewConfigProvider()
+	ldr := file.NewFileLoader(p)
+
+	// The lenient method still accepts a directory without config files.
+	require.NoError(t, ldr.LoadFromDirectory(dir))
+
+	err := ldr.LoadFromDirectoryStrict(dir)
+	require.ErrorIs(t, err, configerrors.ErrNoConfigFilesFound)
+	require.ErrorContains(t, err, dir)
+
+	err = ldr.LoadFromDirectoryStrict(filepath.Join(dir, "missing"))
+	require.ErrorIs(t, err, configerrors.ErrFailedReadDirectory)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "app.yaml"), []byte("app:\n  name: svc\n"), 0o600))
+	require.NoError(t, ldr.LoadFromDirectoryStrict(dir))
+	require.Equal(t, "svc", p.GetKey("app.name"))
+}
+
+func TestFileLoader_DescribeFile(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+	content := `# Application settings.
+app:
+  # Display name shown in logs.
+  Name: demo
+  debug: false
+
+# Listener configuration,
+# applied at startup.
+server:
+  port: 8080 # trailing comments are not descriptions
+`
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+	loader := file.NewFileLoader(viper.NewConfigProvider())
+	descriptions, err := loader.DescribeFile(path)
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{
+		"app":      "Application settings.",
+		"app.name": "Display name shown in logs.",
+		"server":   "Listener configuration, applied at startup.",
+	}, descriptions)
+
+	// Describing a file does not load it.
+	require.False(t, loader.GetProvider().IsSet("app.name"))
+
+	jsonPath := filepath.Join(dir, "config.json")
+	require.NoError(t, os.WriteFile(jsonPath, []byte(`{"a": 1}`), 0o600))
+	_, err = loader.DescribeFile(jsonPath)
+	require.ErrorIs(t, err, configerrors.ErrUnsupportedExtension)
+
+	_, err = loader.DescribeFile(filepath.Join(dir, "missing.yaml"))
+	require.ErrorIs(t, err, configerrors.ErrReadConfigFileFailed)
+}
+
+func TestFileLoader_DuplicateKeyDetection(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name    string
+		file    string
+		content string
+		key     string
+	}{
+		{"yaml", "config.yaml", "db:\n  primary:\n    host: a\n    Host: b\n", "db.primary.Host"},
+		{"json", "config.json", `{"db": {"primary": {"host": "a", "port": 1, "host": "b"}}}`, "db.primary.host"},
+		{"json list", "config.json", `{"servers": [{"name": "a"}, {"name": "b", "name": "c"}]}`, "servers.1.name"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			path := filepath.Join(t.TempDir(), tc.file)
+			require.NoError(t, os.WriteFile(path, []byte(tc.content), 0o600))
+
+			strict := file.NewFileLoader(viper.NewConfigProvider(), file.WithDuplicateKeyDetection())
+			err := strict.LoadFromFile(path)
+			require.ErrorIs(t, err, configerrors.ErrDuplicateKey)
+			require.Contains(t, err.Error(), tc.key)
+			require.Contains(t, err.Error(), path)
+
+			data, err := os.ReadFile(path)
+			require.NoError(t, err)
+
+			err = strict.MergeFromReader(strings.NewReader(string(data)), filepath.Ext(path))
+			require.ErrorIs(t, err, configerrors.ErrDuplicateKey)
+		})
+	}
+
+	// Without the option encoding/json silently keeps the last value.
+	path := filepath.Join(t.TempDir(), "config.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"a": {"b": 1, "b": 2}}`), 0o600))
+	require.NoError(t, file.NewFileLoader(viper.NewConfigProvider()).LoadFromFile(path))
+}
+
+// keyValueParser parses "key=value" lines, with dotted keys for nesting.
+type keyValueParser struct{}
+
+func (keyValueParser) Extensions() []string { return []string{".custom"} }
+
+func (keyValueParser) Parse(data []byte) (map[string]any, error) {
+	result := map[string]any{}
+
+	for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid line %q", line)
+		}
+
+		parts := strings.Split(strings.TrimSpace(key), ".")
+		current := result
+
+		for _, part := range parts[:len(parts)-1] {
+			child, ok := current[part].(map[string]any)
+			if !ok {
+				child = map[string]any{}
+				current[part] = child
+			}
+
+			current = child
+		}
+
+		current[parts[len(parts)-1]] = strings.TrimSpace(value)
+	}
+
+	return result, nil
+}
+
+func TestFileLoader_RegisterParser(t *testing.T) {
+	t.Parallel()
+	file.RegisterParser(keyValueParser{})
+
+	require.True(t, utils.IsSupportedConfigFile("app.custom"))
+	require.True(t, utils.IsSupportedConfigFile("APP.CUSTOM"))
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a.yaml"), []byte("app:\n  name: base\n  port: 80\n"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "b.custom"), []byte("app.name = custom\n"), 0o600))
+
+	prov := viper.NewConfigProvider()
+	loader := file.NewFileLoader(prov)
+	require.NoError(t, loader.LoadFromDirectory(dir))
+	require.Equal(t, "custom", prov.GetKey("app.name"))
+	require.Equal(t, 80, prov.GetKey("app.port"))
+
+	single := viper.NewConfigProvider()
+	require.NoError(t, file.NewFileLoader(single).LoadFromFile(filepath.Join(dir, "b.custom")))
+	require.Equal(t, "custom", single.GetKey("app.name"))
+	require.Equal(t, []string{filepath.Join(dir, "b.custom")}, single.ConfigFilesUsed())
+
+	require.NoError(t, loader.MergeFromReader(strings.NewReader("app.env = test"), "custom"))
+	require.Equal(t, "test", prov.GetKey("app.env"))
+
+	err := loader.MergeFromReader(strings.NewReader("not a pair"), "custom")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "invalid line")
+}
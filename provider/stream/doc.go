@@ -0,0 +1,5 @@
+// Package stream contains a Provider fed by a stream of configuration
+// snapshots, such as a gRPC server-streaming call to a centralized config
+// service. It implements contract.StreamProvider, so a Config reloads on every
+// push after StartStreaming.
+package stream
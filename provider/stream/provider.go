@@ -0,0 +1,135 @@
+package stream
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/next-trace/scg-config/contract"
+	"github.com/next-trace/scg-config/provider/viper"
+)
+
+// ErrClosed is returned by ReadInConfig once the Provider has been closed.
+var ErrClosed = errors.New("stream provider is closed")
+
+// Source is a stream of complete configuration snapshots. A gRPC client
+// stream is adapted by converting each received message into a nested map.
+type Source interface {
+	// Recv blocks until the next snapshot arrives. Any error, such as io.EOF,
+	// ends the stream.
+	Recv() (map[string]any, error)
+
+	// Close ends the stream, unblocking a pending Recv.
+	Close() error
+}
+
+// Provider serves the latest snapshot received from a Source. The first
+// ReadInConfig waits for the initial snapshot and starts receiving in the
+// background; later calls apply the most recent snapshot. Every snapshot
+// replaces the previous settings entirely, including values written via Set.
+type Provider struct {
+	*viper.ConfigProvider
+
+	source    Source
+	updates   chan map[string]any
+	closeOnce sync.Once
+
+	mu      sync.Mutex
+	latest  map[string]any
+	started bool
+	closed  bool
+}
+
+// NewProvider returns a Provider reading snapshots from source.
+func NewProvider(source Source) *Provider {
+	return &Provider{
+		ConfigProvider: viper.NewConfigProvider(),
+		source:         source,
+		updates:        make(chan map[string]any, 1),
+	}
+}
+
+// ReadInConfig applies the latest snapshot, waiting for the first one on the
+// initial call.
+func (p *Provider) ReadInConfig() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.closed {
+		return ErrClosed
+	}
+
+	if !p.started {
+		snapshot, err := p.source.Recv()
+		if err != nil {
+			return fmt.Errorf("provider: failed to receive initial snapshot: %w", err)
+		}
+
+		p.latest = snapshot
+		p.started = true
+
+		go p.receive()
+	}
+
+	return p.Replace(p.latest)
+}
+
+// receive records every snapshot pushed after the initial one and signals it
+// on Updates. Pending signals are coalesced, since ReadInConfig always applies
+// the latest snapshot.
+func (p *Provider) receive() {
+	defer close(p.updates)
+
+	for {
+		snapshot, err := p.source.Recv()
+		if err != nil {
+			return
+		}
+
+		p.mu.Lock()
+		p.latest = snapshot
+		p.mu.Unlock()
+
+		select {
+		case p.updates <- snapshot:
+		default:
+		}
+	}
+}
+
+// Updates returns a channel signaling pushed snapshots. It is closed when the
+// stream ends or the Provider is closed.
+func (p *Provider) Updates() <-chan map[string]any {
+	return p.updates
+}
+
+// Close closes the Source, which also unblocks a ReadInConfig waiting for the
+// initial snapshot. Closing more than once has no effect.
+func (p *Provider) Close() error {
+	var err error
+
+	p.closeOnce.Do(func() {
+		if closeErr := p.source.Close(); closeErr != nil {
+			err = fmt.Errorf("provider: failed to close stream: %w", closeErr)
+		}
+
+		p.mu.Lock()
+		defer p.mu.Unlock()
+
+		p.closed = true
+
+		// Once started, receive closes updates when Recv fails.
+		if !p.started {
+			close(p.updates)
+		}
+	})
+
+	return err
+}
+
+// Interface assertions: this struct implements contract.Provider and
+// contract.StreamProvider.
+var (
+	_ contract.Provider       = (*Provider)(nil)
+	_ contract.StreamProvider = (*Provider)(nil)
+)
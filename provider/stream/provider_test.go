@@ -0,0 +1,94 @@
+package stream_test
+
+import (
+	"errors"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/next-trace/scg-config/provider/stream"
+)
+
+// mockSource is a Source backed by a channel, standing in for a gRPC stream.
+type mockSource struct {
+	snapshots chan map[string]any
+	closed    chan struct{}
+	once      sync.Once
+}
+
+func newMockSource() *mockSource {
+	return &mockSource{snapshots: make(chan map[string]any, 4), closed: make(chan struct{})}
+}
+
+func (m *mockSource) Recv() (map[string]any, error) {
+	select {
+	case snapshot := <-m.snapshots:
+		return snapshot, nil
+	case <-m.closed:
+		return nil, io.EOF
+	}
+}
+
+func (m *mockSource) Close() error {
+	m.once.Do(func() { close(m.closed) })
+
+	return nil
+}
+
+func TestProvider_ReadInConfig_AppliesLatestSnapshot(t *testing.T) {
+	t.Parallel()
+	source := newMockSource()
+	prov := stream.NewProvider(source)
+
+	source.snapshots <- map[string]any{"app": map[string]any{"name": "v1"}}
+	require.NoError(t, prov.ReadInConfig())
+	require.Equal(t, "v1", prov.GetKey("app.name"))
+
+	source.snapshots <- map[string]any{"app": map[string]any{"port": 8080}}
+
+	select {
+	case <-prov.Updates():
+	case <-time.After(time.Second):
+		t.Fatal("no update signaled")
+	}
+
+	require.NoError(t, prov.ReadInConfig())
+	require.Equal(t, 8080, prov.GetKey("app.port"))
+	require.Nil(t, prov.GetKey("app.name"), "snapshots replace previous settings")
+
+	require.NoError(t, prov.Close())
+
+	select {
+	case _, ok := <-prov.Updates():
+		require.False(t, ok)
+	case <-time.After(time.Second):
+		t.Fatal("updates not closed")
+	}
+
+	require.ErrorIs(t, prov.ReadInConfig(), stream.ErrClosed)
+	require.NoError(t, prov.Close())
+}
+
+func TestProvider_Close_UnblocksInitialRead(t *testing.T) {
+	t.Parallel()
+	prov := stream.NewProvider(newMockSource())
+
+	errs := make(chan error, 1)
+	go func() { errs <- prov.ReadInConfig() }()
+
+	require.NoError(t, prov.Close())
+
+	select {
+	case err := <-errs:
+		require.Error(t, err)
+		require.True(t, errors.Is(err, io.EOF) || errors.Is(err, stream.ErrClosed))
+	case <-time.After(time.Second):
+		t.Fatal("ReadInConfig still blocked after Close")
+	}
+
+	_, ok := <-prov.Updates()
+	require.False(t, ok)
+}
@@ -0,0 +1,37 @@
+package viper
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/next-trace/scg-config/utils"
+)
+
+// jsonCodec is Viper's JSON codec with exact integers: it decodes numbers via
+// utils.UnmarshalJSON, so large int64 values (e.g. IDs beyond 2^53) survive
+// instead of being rounded through float64.
+type jsonCodec struct{}
+
+// Encode renders v as indented JSON, like Viper's own codec.
+func (jsonCodec) Encode(v map[string]any) ([]byte, error) {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("provider: failed to encode JSON: %w", err)
+	}
+
+	return data, nil
+}
+
+// Decode parses the JSON object in b into v.
+func (jsonCodec) Decode(b []byte, v map[string]any) error {
+	decoded, err := utils.UnmarshalJSON(b)
+	if err != nil {
+		return fmt.Errorf("provider: %w", err)
+	}
+
+	for key, value := range decoded {
+		v[key] = value
+	}
+
+	return nil
+}
@@ -3,7 +3,13 @@ package viper
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/spf13/viper"
 
@@ -12,52 +18,269 @@ import (
 
 // ConfigProvider implements contract.Provider using Viper.
 type ConfigProvider struct {
+	// mu guards every access to v, which is not safe for concurrent use, and
+	// the config file tracking below.
+	mu            sync.RWMutex
 	v             *viper.Viper
-	configFileSet bool // tracks if a config file path was explicitly set
+	envReplacer   *strings.Replacer
+	automaticEnv  bool     // environment variables override the settings
+	configFileSet bool     // tracks if a config file path was explicitly set
+	mergedFiles   []string // files merged over the config file, in order
+
+	// Origins are tracked per Viper precedence layer: values written via Set
+	// override environment variables, which override file values.
+	originMu    sync.RWMutex
+	setOrigins  map[string]string
+	fileOrigins map[string]string
+}
+
+// Option is a functional option for configuring the ConfigProvider.
+type Option func(*ConfigProvider)
+
+// WithoutAutomaticEnv disables the automatic environment variable lookup, so
+// only values that were loaded or set explicitly are served. This keeps tests
+// hermetic: a fixture key such as "home" is not overridden by $HOME.
+func WithoutAutomaticEnv() Option {
+	return func(cp *ConfigProvider) { cp.automaticEnv = false }
 }
 
 // NewConfigProvider returns a new ConfigProvider instance (satisfies contract.Provider).
 // It configures Viper for ENV-first operation with automatic environment variable support.
-func NewConfigProvider() *ConfigProvider {
-	v := viper.New()
-
-	// Enable automatic environment variable reading (ENV-first, 12-factor compliant)
-	v.AutomaticEnv()
+// JSON files are decoded with exact integers (int64 rather than float64).
+func NewConfigProvider(opts ...Option) *ConfigProvider {
+	v := newViper()
 
 	// Replace '.' and '-' with '_' in env var names for consistent key mapping
 	// e.g., "app.name" or "app-name" will match env var "APP_NAME"
-	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_", "-", "_"))
+	envReplacer := strings.NewReplacer(".", "_", "-", "_")
+	v.SetEnvKeyReplacer(envReplacer)
 
-	return &ConfigProvider{
+	cp := &ConfigProvider{
+		mu:            sync.RWMutex{},
 		v:             v,
+		envReplacer:   envReplacer,
+		automaticEnv:  true,
 		configFileSet: false,
+		originMu:      sync.RWMutex{},
+		setOrigins:    make(map[string]string),
+		fileOrigins:   make(map[string]string),
+	}
+	for _, opt := range opts {
+		opt(cp)
 	}
+
+	// Enable automatic environment variable reading (ENV-first, 12-factor compliant)
+	if cp.automaticEnv {
+		v.AutomaticEnv()
+	}
+
+	return cp
+}
+
+// newViper returns a Viper instance whose JSON codec keeps integers exact.
+func newViper() *viper.Viper {
+	codecs := viper.NewCodecRegistry()
+	_ = codecs.RegisterCodec("json", jsonCodec{}) // never fails for DefaultCodecRegistry
+
+	return viper.NewWithOptions(viper.WithCodecRegistry(codecs))
 }
 
 // AllSettings returns the entire config as a nested map.
 func (cp *ConfigProvider) AllSettings() map[string]interface{} {
+	cp.mu.RLock()
+	defer cp.mu.RUnlock()
+
 	return cp.v.AllSettings()
 }
 
+// Snapshot returns a deep copy of AllSettings. Maps and slices are copied
+// recursively, so callers may mutate the result without affecting the provider.
+func (cp *ConfigProvider) Snapshot() map[string]interface{} {
+	snapshot, _ := deepCopy(cp.AllSettings()).(map[string]interface{})
+
+	return snapshot
+}
+
+// deepCopy returns a copy of v in which every map and slice, at any depth, is
+// newly allocated. Other values are returned as is.
+func deepCopy(v any) any {
+	value := reflect.ValueOf(v)
+
+	switch value.Kind() {
+	case reflect.Map:
+		if value.IsNil() {
+			return v
+		}
+
+		result := reflect.MakeMapWithSize(value.Type(), value.Len())
+		for iter := value.MapRange(); iter.Next(); {
+			result.SetMapIndex(iter.Key(), copyValue(iter.Value(), value.Type().Elem()))
+		}
+
+		return result.Interface()
+	case reflect.Slice:
+		if value.IsNil() {
+			return v
+		}
+
+		result := reflect.MakeSlice(value.Type(), value.Len(), value.Len())
+		for i := range value.Len() {
+			result.Index(i).Set(copyValue(value.Index(i), value.Type().Elem()))
+		}
+
+		return result.Interface()
+	default:
+		return v
+	}
+}
+
+// copyValue deep-copies a map or slice element of type typ.
+func copyValue(elem reflect.Value, typ reflect.Type) reflect.Value {
+	copied := deepCopy(elem.Interface())
+	if copied == nil {
+		return reflect.Zero(typ)
+	}
+
+	return reflect.ValueOf(copied).Convert(typ)
+}
+
 // GetKey returns the value for a key (flat lookup only, for bootstrapping and tests).
 func (cp *ConfigProvider) GetKey(key string) any {
+	cp.mu.RLock()
+	defer cp.mu.RUnlock()
+
 	return cp.v.Get(key)
 }
 
+// GetString returns the value for key as a string using Viper's native
+// conversion. This is a provider-level convenience for bootstrapping code that
+// runs before a Config or Getter exists; it is not part of contract.Provider.
+func (cp *ConfigProvider) GetString(key string) string {
+	cp.mu.RLock()
+	defer cp.mu.RUnlock()
+
+	return cp.v.GetString(key)
+}
+
+// GetInt returns the value for key as an int using Viper's native conversion,
+// so env-sourced strings like "8080" are converted. Provider-level convenience;
+// not part of contract.Provider.
+func (cp *ConfigProvider) GetInt(key string) int {
+	cp.mu.RLock()
+	defer cp.mu.RUnlock()
+
+	return cp.v.GetInt(key)
+}
+
+// GetBool returns the value for key as a bool using Viper's native conversion.
+// Provider-level convenience; not part of contract.Provider.
+func (cp *ConfigProvider) GetBool(key string) bool {
+	cp.mu.RLock()
+	defer cp.mu.RUnlock()
+
+	return cp.v.GetBool(key)
+}
+
+// GetDuration returns the value for key as a time.Duration using Viper's
+// native conversion. Provider-level convenience; not part of contract.Provider.
+func (cp *ConfigProvider) GetDuration(key string) time.Duration {
+	cp.mu.RLock()
+	defer cp.mu.RUnlock()
+
+	return cp.v.GetDuration(key)
+}
+
+// GetStringSlice returns the value for key as a []string using Viper's native
+// conversion. Provider-level convenience; not part of contract.Provider.
+func (cp *ConfigProvider) GetStringSlice(key string) []string {
+	cp.mu.RLock()
+	defer cp.mu.RUnlock()
+
+	return cp.v.GetStringSlice(key)
+}
+
 // IsSet checks if a config key is present (flat lookup).
 func (cp *ConfigProvider) IsSet(key string) bool {
+	cp.mu.RLock()
+	defer cp.mu.RUnlock()
+
 	return cp.v.IsSet(key)
 }
 
 // Set sets a key in the Viper store (for tests or live editing).
+// The key's origin is recorded as contract.OriginSet.
 func (cp *ConfigProvider) Set(key string, value any) {
+	cp.mu.Lock()
 	cp.v.Set(key, value)
+	cp.mu.Unlock()
+
+	cp.RecordOrigin(key, contract.OriginSet)
+}
+
+// RecordOrigin records source as the origin of key. Sources prefixed with
+// contract.OriginFilePrefix are tracked at file precedence; all others are
+// tracked at Set precedence, matching where the loaders write the value.
+func (cp *ConfigProvider) RecordOrigin(key, source string) {
+	cp.originMu.Lock()
+	defer cp.originMu.Unlock()
+
+	if strings.HasPrefix(source, contract.OriginFilePrefix) {
+		cp.fileOrigins[strings.ToLower(key)] = source
+
+		return
+	}
+
+	cp.setOrigins[strings.ToLower(key)] = source
+}
+
+// Origin returns where the effective value of key came from, following Viper
+// precedence: values written via Set, then automatic environment variables,
+// then files. Parent keys are consulted so that a key inside a map written as
+// a whole reports the origin of that map.
+func (cp *ConfigProvider) Origin(key string) (string, bool) {
+	key = strings.ToLower(key)
+
+	cp.originMu.RLock()
+	defer cp.originMu.RUnlock()
+
+	if source, ok := lookupOrigin(cp.setOrigins, key); ok {
+		return source, true
+	}
+
+	if cp.automaticEnv {
+		envName := strings.ToUpper(cp.envReplacer.Replace(key))
+		if _, ok := os.LookupEnv(envName); ok {
+			return contract.OriginEnvPrefix + envName, true
+		}
+	}
+
+	return lookupOrigin(cp.fileOrigins, key)
+}
+
+// lookupOrigin finds the origin of key or of its closest recorded parent.
+func lookupOrigin(origins map[string]string, key string) (string, bool) {
+	for {
+		if source, ok := origins[key]; ok {
+			return source, true
+		}
+
+		idx := strings.LastIndex(key, ".")
+		if idx < 0 {
+			return "", false
+		}
+
+		key = key[:idx]
+	}
 }
 
 // ReadInConfig reloads from file/env if supported by Viper.
 // If no config file is set, this is a no-op (environment-only mode).
-// Environment variables are always read automatically via AutomaticEnv().
+// Environment variables are always read automatically via AutomaticEnv(),
+// unless the provider was created WithoutAutomaticEnv.
 func (cp *ConfigProvider) ReadInConfig() error {
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+
 	// Only try to read config file if one was explicitly set
 	// This implements ENV-first: environment variables work without any config file
 	if !cp.configFileSet {
@@ -75,13 +298,111 @@ func (cp *ConfigProvider) ReadInConfig() error {
 }
 
 // SetConfigFile sets which file to read and marks file config as enabled.
+// Files merged over a previously set config file are forgotten.
 func (cp *ConfigProvider) SetConfigFile(file string) {
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+
 	cp.v.SetConfigFile(file)
+	// Replace may have left an explicit config type behind; pin the one Viper
+	// infers from the extension.
+	cp.v.SetConfigType(configType(file))
 	cp.configFileSet = true
+	cp.mergedFiles = nil
+}
+
+// ConfigFilesUsed returns the config file set via SetConfigFile followed by
+// the files recorded via RecordConfigFile, e.g. every file read by
+// LoadFromDirectory. It returns an empty slice when no file was loaded.
+func (cp *ConfigProvider) ConfigFilesUsed() []string {
+	cp.mu.RLock()
+	defer cp.mu.RUnlock()
+
+	files := make([]string, 0, len(cp.mergedFiles)+1)
+	if cp.configFileSet && cp.v.ConfigFileUsed() != "" {
+		files = append(files, cp.v.ConfigFileUsed())
+	}
+
+	return append(files, cp.mergedFiles...)
+}
+
+// RecordConfigFile records path as merged into the settings, for
+// ConfigFilesUsed. Recording the same path again has no effect.
+func (cp *ConfigProvider) RecordConfigFile(path string) {
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+
+	if slices.Contains(cp.mergedFiles, path) || (cp.configFileSet && cp.v.ConfigFileUsed() == path) {
+		return
+	}
+
+	cp.mergedFiles = append(cp.mergedFiles, path)
+}
+
+// Replace discards every setting, including values written via Set, and
+// loads configMap in their place, so keys missing from configMap are gone
+// afterwards. The settings are replaced in place: defaults and environment
+// bindings made on the underlying Viper instance, the config file association
+// and the recorded config files are kept, as are the origins of keys that are
+// still present.
+func (cp *ConfigProvider) Replace(configMap map[string]interface{}) error {
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+
+	// Viper cannot delete values written via Set, but a nil value falls
+	// through to the lower layers as if it had never been set.
+	for key := range cp.v.AllSettings() {
+		cp.v.Set(key, nil)
+	}
+
+	if err := cp.clearConfigLayer(); err != nil {
+		return err
+	}
+
+	if err := cp.v.MergeConfigMap(configMap); err != nil {
+		return fmt.Errorf("provider: failed to replace config map: %w", err)
+	}
+
+	cp.originMu.Lock()
+	defer cp.originMu.Unlock()
+
+	for _, origins := range []map[string]string{cp.setOrigins, cp.fileOrigins} {
+		for key := range origins {
+			if !cp.v.IsSet(key) {
+				delete(origins, key)
+			}
+		}
+	}
+
+	return nil
+}
+
+// clearConfigLayer empties the settings Viper holds for its config file.
+// ReadConfig is the only way to do so, and it decodes in the configured type,
+// so an empty JSON document is read and the type inferred from the config
+// file is restored afterwards.
+func (cp *ConfigProvider) clearConfigLayer() error {
+	cp.v.SetConfigType("json")
+
+	if err := cp.v.ReadConfig(strings.NewReader("{}")); err != nil {
+		return fmt.Errorf("provider: failed to clear config: %w", err)
+	}
+
+	cp.v.SetConfigType(configType(cp.v.ConfigFileUsed()))
+
+	return nil
+}
+
+// configType returns the config type Viper infers from the extension of file.
+func configType(file string) string {
+	return strings.TrimPrefix(filepath.Ext(file), ".")
 }
 
 // MergeConfigMap merges another map into config.
 func (cp *ConfigProvider) MergeConfigMap(configMap map[string]interface{}) error {
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+
 	if err := cp.v.MergeConfigMap(configMap); err != nil {
 		return fmt.Errorf("provider: failed to merge config map: %w", err)
 	}
@@ -89,10 +410,18 @@ func (cp *ConfigProvider) MergeConfigMap(configMap map[string]interface{}) error
 	return nil
 }
 
-// Provider returns the underlying Viper object for advanced use.
+// Provider returns the underlying Viper object for advanced use. Access
+// through it bypasses the provider's locking, so it must not race with other
+// provider calls.
 func (cp *ConfigProvider) Provider() any {
 	return cp.v
 }
 
-// Interface assertion: this struct implements contract.Provider.
-var _ contract.Provider = (*ConfigProvider)(nil)
+// Interface assertions: this struct implements contract.Provider, contract.OriginTracker,
+// contract.ConfigFileTracker and contract.Replacer.
+var (
+	_ contract.Provider          = (*ConfigProvider)(nil)
+	_ contract.OriginTracker     = (*ConfigProvider)(nil)
+	_ contract.ConfigFileTracker = (*ConfigProvider)(nil)
+	_ contract.Replacer          = (*ConfigProvider)(nil)
+)
@@ -4,7 +4,9 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
+	spfviper "github.com/spf13/viper"
 	"github.com/stretchr/testify/require"
 
 	"github.com/next-trace/scg-config/provider/viper"
@@ -98,6 +100,21 @@ func TestConfigProvider_Provider_ReturnsViper(t *testing.T) {
 
 // --- ENV-first configuration tests ---
 
+func TestConfigProvider_WithoutAutomaticEnv(t *testing.T) {
+	// Note: Cannot use t.Parallel() with t.Setenv()
+	t.Setenv("APP_NAME", "from-env")
+	t.Setenv("APP_PORT", "9090")
+
+	provider := viper.NewConfigProvider(viper.WithoutAutomaticEnv())
+	require.NoError(t, provider.MergeConfigMap(map[string]any{"app": map[string]any{"name": "from-map"}}))
+
+	require.Equal(t, "from-map", provider.GetString("app.name"))
+	require.False(t, provider.IsSet("app.port"))
+
+	_, found := provider.Origin("app.port")
+	require.False(t, found)
+}
+
 // TestConfigProvider_EnvOnly_NoConfigFile verifies that the provider works with
 // environment variables only, without any config file set (ENV-first, no panic).
 func TestConfigProvider_EnvOnly_NoConfigFile(t *testing.T) {
@@ -211,3 +228,96 @@ func TestConfigProvider_EnvOverridesFile(t *testing.T) {
 	// Key only in file should still work
 	require.Equal(t, "1.0", p.GetKey("app.version"))
 }
+
+// TestConfigProvider_TypedGetters verifies provider-level typed conveniences
+// convert env-sourced strings natively.
+func TestConfigProvider_TypedGetters(t *testing.T) {
+	// Note: Cannot use t.Parallel() with t.Setenv()
+	t.Setenv("SERVICE_PORT", "8080")
+	t.Setenv("SERVICE_DEBUG", "true")
+	t.Setenv("SERVICE_TIMEOUT", "5s")
+
+	p := viper.NewConfigProvider()
+	p.Set("service.hosts", []any{"a", "b"})
+
+	require.Equal(t, 8080, p.GetInt("service.port"))
+	require.True(t, p.GetBool("service.debug"))
+	require.Equal(t, "8080", p.GetString("service.port"))
+	require.Equal(t, 5*time.Second, p.GetDuration("service.timeout"))
+	require.Equal(t, []string{"a", "b"}, p.GetStringSlice("service.hosts"))
+	require.Equal(t, 0, p.GetInt("service.missing"))
+}
+
+func TestConfigProvider_SnapshotIsDeepCopy(t *testing.T) {
+	t.Parallel()
+
+	provider := viper.NewConfigProvider()
+	require.NoError(t, provider.MergeConfigMap(map[string]any{
+		"db":    map[string]any{"host": "localhost", "replicas": []any{"r1", "r2"}},
+		"tags":  []string{"a", "b"},
+		"empty": nil,
+	}))
+
+	snapshot := provider.Snapshot()
+	db, ok := snapshot["db"].(map[string]any)
+	require.True(t, ok)
+	db["host"] = "mutated"
+	replicas, ok := db["replicas"].([]any)
+	require.True(t, ok)
+	replicas[0] = "mutated"
+	tags, ok := snapshot["tags"].([]string)
+	require.True(t, ok)
+	tags[0] = "mutated"
+
+	require.Equal(t, "localhost", provider.GetKey("db.host"))
+	require.Equal(t, []any{"r1", "r2"}, provider.GetKey("db.replicas"))
+	require.Equal(t, []string{"a", "b"}, provider.GetKey("tags"))
+	require.Equal(t, "localhost", provider.Snapshot()["db"].(map[string]any)["host"])
+}
+
+func TestConfigProvider_Replace_InPlace(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("app:\n  port: 1\n"), 0o600))
+	extra := filepath.Join(dir, "extra.yaml")
+
+	provider := viper.NewConfigProvider()
+	provider.SetConfigFile(path)
+	require.NoError(t, provider.ReadInConfig())
+	provider.RecordConfigFile(extra)
+	provider.Set("app.name", "set")
+	provider.Set("stale", "gone")
+
+	raw, ok := provider.Provider().(*spfviper.Viper)
+	require.True(t, ok)
+	raw.SetDefault("timeout", "5s")
+
+	require.NoError(t, provider.Replace(map[string]any{
+		"app":  map[string]any{"port": 2},
+		"name": "replaced",
+	}))
+
+	require.Equal(t, map[string]any{
+		"app":     map[string]any{"port": 2},
+		"name":    "replaced",
+		"timeout": "5s",
+	}, provider.AllSettings())
+	require.False(t, provider.IsSet("app.name"))
+	require.False(t, provider.IsSet("stale"))
+	require.Same(t, raw, provider.Provider())
+	require.Equal(t, []string{path, extra}, provider.ConfigFilesUsed())
+
+	_, found := provider.Origin("stale")
+	require.False(t, found)
+
+	// Values written after a Replace take precedence again.
+	provider.Set("app.port", 3)
+	require.Equal(t, 3, provider.GetInt("app.port"))
+
+	// The config file is still read in its own format, replacing the file layer.
+	require.NoError(t, provider.ReadInConfig())
+	require.False(t, provider.IsSet("name"))
+	require.Equal(t, 3, provider.GetInt("app.port"))
+}
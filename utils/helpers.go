@@ -5,12 +5,14 @@
 package utils
 
 import (
+	"encoding/json"
 	"fmt"
 	"math"
 	"net/url"
 	"path/filepath"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/google/uuid"
@@ -24,8 +26,52 @@ const (
 )
 
 // NormalizeEnvKey converts an environment variable key (e.g. APP_NAME) to dot notation (e.g. app.name).
+// Consecutive, leading and trailing separators are dropped so the result never
+// contains empty segments (e.g. __APP__NAME_ becomes app.name).
 func NormalizeEnvKey(key string) string {
-	return strings.ToLower(strings.ReplaceAll(key, "_", "."))
+	segments := strings.FieldsFunc(strings.ToLower(key), func(r rune) bool {
+		return r == '_' || r == '.'
+	})
+
+	return strings.Join(segments, ".")
+}
+
+// NormalizeEnvKeyWithSeparator converts an environment variable key to dot
+// notation using separator to denote nesting. Single underscores within a
+// segment are preserved when separator is not "_", so with separator "__"
+// DB__MAX_CONNS becomes db.max_conns. An empty or "_" separator behaves like
+// NormalizeEnvKey.
+func NormalizeEnvKeyWithSeparator(key, separator string) string {
+	if separator == "" || separator == "_" {
+		return NormalizeEnvKey(key)
+	}
+
+	var segments []string
+
+	for _, part := range strings.Split(strings.ToLower(key), separator) {
+		for _, segment := range strings.FieldsFunc(part, func(r rune) bool { return r == '.' }) {
+			if segment = strings.Trim(segment, "_"); segment != "" {
+				segments = append(segments, segment)
+			}
+		}
+	}
+
+	return strings.Join(segments, ".")
+}
+
+// NormalizePrefixWithSeparator prepares the prefix for env matching when keys
+// use separator for nesting (e.g. "app" with "__" becomes "APP__").
+func NormalizePrefixWithSeparator(prefix, separator string) string {
+	if separator == "" {
+		separator = "_"
+	}
+
+	prefix = strings.ToUpper(prefix)
+	if prefix != "" {
+		return prefix + separator
+	}
+
+	return ""
 }
 
 // NormalizePrefix prepares the prefix for env matching.
@@ -62,13 +108,34 @@ func StripPrefix(key, prefix string) string {
 	return key
 }
 
-// IsSupportedConfigFile returns true if the file has a supported config extension.
+// extraExtensions holds the config file extensions added via
+// RegisterConfigExtension, lower-cased with a leading dot.
+var (
+	extraExtensionsMu sync.RWMutex
+	extraExtensions   = make(map[string]bool)
+)
+
+// RegisterConfigExtension makes IsSupportedConfigFile accept files with ext
+// (e.g. ".toml" or "toml"), matched case-insensitively. The file loader calls
+// it for the extensions of every registered format parser.
+func RegisterConfigExtension(ext string) {
+	extraExtensionsMu.Lock()
+	defer extraExtensionsMu.Unlock()
+
+	extraExtensions["."+strings.TrimPrefix(strings.ToLower(ext), ".")] = true
+}
+
+// IsSupportedConfigFile returns true if the file has a supported config
+// extension: YAML, JSON or one added via RegisterConfigExtension.
 func IsSupportedConfigFile(filename string) bool {
 	switch filepath.Ext(filename) {
 	case contract.ExtYAML, contract.ExtYML, contract.ExtJSON:
 		return true
 	default:
-		return false
+		extraExtensionsMu.RLock()
+		defer extraExtensionsMu.RUnlock()
+
+		return extraExtensions[strings.ToLower(filepath.Ext(filename))]
 	}
 }
 
@@ -104,6 +171,9 @@ func ToInt(val any) (int, error) {
 		converted := i
 
 		return converted, nil
+	case json.Number:
+		// Parse the literal as a string, with the same range checks.
+		return ToInt(value.String())
 	default:
 		return 0, configerrors.ErrNotInt
 	}
@@ -128,6 +198,9 @@ func ToInt32(val any) (int32, error) {
 		}
 		// At this point the parsed value is guaranteed to fit into 32‑bits.
 		return int32(int64Value), nil
+	case json.Number:
+		// Parse the literal as a string, with the same range checks.
+		return ToInt32(value.String())
 	default:
 		return 0, configerrors.ErrNotInt32
 	}
@@ -153,6 +226,9 @@ func ToInt64(val any) (int64, error) {
 		}
 
 		return intValue, nil
+	case json.Number:
+		// Parse the literal as a string, with the same range checks.
+		return ToInt64(value.String())
 	default:
 		return 0, configerrors.ErrNotInt64
 	}
@@ -180,6 +256,9 @@ func ToUint(val any) (uint, error) {
 		}
 
 		return uint(intVal), nil
+	case json.Number:
+		// Parse the literal as a string, with the same range checks.
+		return ToUint(value.String())
 	default:
 		return 0, configerrors.ErrNotUint
 	}
@@ -204,6 +283,9 @@ func ToUint32(val any) (uint32, error) {
 		}
 
 		return uint32(i), nil
+	case json.Number:
+		// Parse the literal as a string, with the same range checks.
+		return ToUint32(value.String())
 	default:
 		return 0, configerrors.ErrNotUint32
 	}
@@ -228,6 +310,9 @@ func ToUint64(val any) (uint64, error) {
 		}
 
 		return i, nil
+	case json.Number:
+		// Parse the literal as a string, with the same range checks.
+		return ToUint64(value.String())
 	default:
 		return 0, configerrors.ErrNotUint64
 	}
@@ -255,6 +340,9 @@ func ToFloat32(val any) (float32, error) {
 		}
 
 		return float32(floatVal), nil
+	case json.Number:
+		// Parse the literal as a string, with the same range checks.
+		return ToFloat32(value.String())
 	default:
 		return 0, configerrors.ErrNotFloat32
 	}
@@ -274,6 +362,9 @@ func ToFloat64(val any) (float64, error) {
 		}
 
 		return f, nil
+	case json.Number:
+		// Parse the literal as a string, with the same range checks.
+		return ToFloat64(value.String())
 	default:
 		return 0, configerrors.ErrNotFloat64
 	}
@@ -305,7 +396,32 @@ func ToBool(val any) (bool, error) {
 	}
 }
 
+// DefaultListDelimiter separates elements of lists given as a single string,
+// such as APP_ROLES=admin,user.
+const DefaultListDelimiter = ","
+
+// SplitList splits s on delim (DefaultListDelimiter when empty), trimming
+// whitespace around elements and dropping empty ones. An empty or blank s
+// yields an empty, non-nil slice.
+func SplitList(s, delim string) []string {
+	if delim == "" {
+		delim = DefaultListDelimiter
+	}
+
+	result := []string{}
+
+	for _, part := range strings.Split(s, delim) {
+		if trimmed := strings.TrimSpace(part); trimmed != "" {
+			result = append(result, trimmed)
+		}
+	}
+
+	return result
+}
+
 // ToStringSlice converts val to a slice of strings, validating each element.
+// A single string is not a slice; use ToStringSliceDelim to split lists given
+// as one string, e.g. from environment variables.
 func ToStringSlice(val any) ([]string, error) {
 	switch value := val.(type) {
 	case []string:
@@ -328,15 +444,112 @@ func ToStringSlice(val any) ([]string, error) {
 	}
 }
 
+// ToStringSliceDelim behaves like ToStringSlice but also accepts a single
+// string, which is split on delim (a comma when empty) via SplitList.
+func ToStringSliceDelim(val any, delim string) ([]string, error) {
+	if text, ok := val.(string); ok {
+		return SplitList(text, delim), nil
+	}
+
+	return ToStringSlice(val)
+}
+
 // ToMap converts val to map[string]any.
 func ToMap(val any) (map[string]any, error) {
-	if m, ok := val.(map[string]any); ok {
+	if m, ok := NormalizeMap(val).(map[string]any); ok {
 		return m, nil
 	}
 
 	return nil, configerrors.ErrNotMap
 }
 
+// NormalizeMap recursively converts every map[interface{}]interface{} in v (as
+// produced by some YAML decoders) to map[string]any, descending into maps and
+// slices. Non-string keys are formatted with fmt.Sprint. Other values are
+// returned unchanged.
+func NormalizeMap(v any) any {
+	switch value := v.(type) {
+	case map[interface{}]interface{}:
+		result := make(map[string]any, len(value))
+		for key, elem := range value {
+			result[fmt.Sprint(key)] = NormalizeMap(elem)
+		}
+
+		return result
+	case map[string]any:
+		result := make(map[string]any, len(value))
+		for key, elem := range value {
+			result[key] = NormalizeMap(elem)
+		}
+
+		return result
+	case []any:
+		result := make([]any, len(value))
+		for i, elem := range value {
+			result[i] = NormalizeMap(elem)
+		}
+
+		return result
+	default:
+		return v
+	}
+}
+
+// ToStringMapStringSlice converts val to map[string][]string, converting each
+// value via ToStringSlice.
+func ToStringMapStringSlice(val any) (map[string][]string, error) {
+	switch value := val.(type) {
+	case map[string][]string:
+		return value, nil
+	case map[string]any:
+		result := make(map[string][]string, len(value))
+
+		for key, elem := range value {
+			slice, err := ToStringSlice(elem)
+			if err != nil {
+				return nil, fmt.Errorf("%w: key %q: %w", configerrors.ErrNotStringMapStringSlice, key, err)
+			}
+
+			result[key] = slice
+		}
+
+		return result, nil
+	default:
+		return nil, configerrors.ErrNotStringMapStringSlice
+	}
+}
+
+// ToTypedMap converts val to map[string]T, converting each value with conv.
+// It returns ErrNotMap for non-map inputs and a per-key error wrapping the
+// converter's error when a value cannot be converted.
+func ToTypedMap[T any](val any, conv func(any) (T, error)) (map[string]T, error) {
+	switch value := val.(type) {
+	case map[string]T:
+		return value, nil
+	case map[string]any:
+		result := make(map[string]T, len(value))
+
+		for key, elem := range value {
+			converted, err := conv(elem)
+			if err != nil {
+				return nil, fmt.Errorf("key %q: %w", key, err)
+			}
+
+			result[key] = converted
+		}
+
+		return result, nil
+	default:
+		return nil, configerrors.ErrNotMap
+	}
+}
+
+// ToStringMapInt converts val to map[string]int, converting each value via ToInt
+// so that string values loaded from the environment (e.g. "100") are accepted.
+func ToStringMapInt(val any) (map[string]int, error) {
+	return ToTypedMap(val, ToInt)
+}
+
 // ToTime converts val to time.Time.
 func ToTime(val any) (time.Time, error) {
 	if t, ok := val.(time.Time); ok {
@@ -346,13 +559,53 @@ func ToTime(val any) (time.Time, error) {
 	return time.Time{}, configerrors.ErrNotTime
 }
 
-// ToDuration converts val to time.Duration.
+// ToDuration converts val to time.Duration. Strings are parsed with
+// time.ParseDuration (e.g. "1s", "1m30s").
 func ToDuration(val any) (time.Duration, error) {
-	if d, ok := val.(time.Duration); ok {
+	switch value := val.(type) {
+	case time.Duration:
+		return value, nil
+	case string:
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return 0, fmt.Errorf("%w: %w", configerrors.ErrNotDuration, err)
+		}
+
 		return d, nil
+	default:
+		return 0, configerrors.ErrNotDuration
+	}
+}
+
+// ToDurationSlice converts val to a slice of durations, converting each element
+// via ToDuration and reporting the index of the first element that fails.
+func ToDurationSlice(val any) ([]time.Duration, error) {
+	switch value := val.(type) {
+	case []time.Duration:
+		return value, nil
+	case []string:
+		return toDurationSlice(value)
+	case []any:
+		return toDurationSlice(value)
+	default:
+		return nil, configerrors.ErrNotDurationSlice
+	}
+}
+
+// toDurationSlice converts every element of values via ToDuration.
+func toDurationSlice[E any](values []E) ([]time.Duration, error) {
+	result := make([]time.Duration, len(values))
+
+	for idx, elem := range values {
+		d, err := ToDuration(elem)
+		if err != nil {
+			return nil, fmt.Errorf("%w: element %d: %w", configerrors.ErrNotDurationSlice, idx, err)
+		}
+
+		result[idx] = d
 	}
 
-	return 0, configerrors.ErrNotDuration
+	return result, nil
 }
 
 // ToBytes converts val to a byte slice.
@@ -1,7 +1,9 @@
 package utils_test
 
 import (
+	"encoding/json"
 	"net/url"
+	"strings"
 	"testing"
 	"time"
 
@@ -19,10 +21,21 @@ func TestEnvHelpers(t *testing.T) {
 	// NormalizeEnvKey
 	require.Equal(t, "app.name", utils.NormalizeEnvKey("APP_NAME"))
 	require.Equal(t, "a.b.c", utils.NormalizeEnvKey("A_B_C"))
+	require.Equal(t, "app.name", utils.NormalizeEnvKey("__APP__NAME_"))
+	require.Equal(t, "a.b", utils.NormalizeEnvKey("A._B"))
+	require.Equal(t, "", utils.NormalizeEnvKey("___"))
+
+	// NormalizeEnvKeyWithSeparator
+	require.Equal(t, "db.max_conns", utils.NormalizeEnvKeyWithSeparator("DB__MAX_CONNS", "__"))
+	require.Equal(t, "db.host", utils.NormalizeEnvKeyWithSeparator("__DB____HOST", "__"))
+	require.Equal(t, "db.max.conns", utils.NormalizeEnvKeyWithSeparator("DB_MAX_CONNS", "_"))
+	require.Equal(t, "db.max.conns", utils.NormalizeEnvKeyWithSeparator("DB_MAX_CONNS", ""))
 
 	// NormalizePrefix
 	require.Equal(t, "APP_", utils.NormalizePrefix("app"))
 	require.Equal(t, "", utils.NormalizePrefix(""))
+	require.Equal(t, "APP__", utils.NormalizePrefixWithSeparator("app", "__"))
+	require.Equal(t, "APP_", utils.NormalizePrefixWithSeparator("app", ""))
 
 	// ShouldProcessEnv
 	require.True(t, utils.ShouldProcessEnv("APP_NAME=ok", ""))
@@ -274,3 +287,243 @@ func TestIsSupportedConfigFile_Extensions(t *testing.T) {
 		})
 	}
 }
+
+func TestToStringMapStringSlice(t *testing.T) {
+	t.Parallel()
+
+	typed := map[string][]string{"api": {"/v1"}}
+	m, err := utils.ToStringMapStringSlice(typed)
+	require.NoError(t, err)
+	require.Equal(t, typed, m)
+
+	m, err = utils.ToStringMapStringSlice(map[string]any{"api": []any{"/v1", "/v2"}, "web": []string{"/"}})
+	require.NoError(t, err)
+	require.Equal(t, map[string][]string{"api": {"/v1", "/v2"}, "web": {"/"}}, m)
+
+	_, err = utils.ToStringMapStringSlice(map[string]any{"api": "/v1"})
+	require.ErrorIs(t, err, configerrors.ErrNotStringMapStringSlice)
+	require.ErrorIs(t, err, configerrors.ErrNotStringSlice)
+	_, err = utils.ToStringMapStringSlice(map[string]any{"api": []any{1}})
+	require.ErrorIs(t, err, configerrors.ErrNotStringInSlice)
+	_, err = utils.ToStringMapStringSlice("nope")
+	require.ErrorIs(t, err, configerrors.ErrNotStringMapStringSlice)
+}
+
+func TestToStringMapInt(t *testing.T) {
+	t.Parallel()
+
+	m, err := utils.ToStringMapInt(map[string]any{"read": 100, "write": "50"})
+	require.NoError(t, err)
+	require.Equal(t, map[string]int{"read": 100, "write": 50}, m)
+
+	typed := map[string]int{"read": 1}
+	m, err = utils.ToStringMapInt(typed)
+	require.NoError(t, err)
+	require.Equal(t, typed, m)
+
+	_, err = utils.ToStringMapInt(map[string]any{"read": "lots"})
+	require.ErrorIs(t, err, configerrors.ErrNotInt)
+	require.Contains(t, err.Error(), `"read"`)
+	_, err = utils.ToStringMapInt([]int{1})
+	require.ErrorIs(t, err, configerrors.ErrNotMap)
+}
+
+func TestToTypedMap(t *testing.T) {
+	t.Parallel()
+
+	m, err := utils.ToTypedMap(map[string]any{"on": "true", "off": false}, utils.ToBool)
+	require.NoError(t, err)
+	require.Equal(t, map[string]bool{"on": true, "off": false}, m)
+}
+
+func TestToDuration_ParsesStrings(t *testing.T) {
+	t.Parallel()
+
+	d, err := utils.ToDuration("1m30s")
+	require.NoError(t, err)
+	require.Equal(t, 90*time.Second, d)
+
+	_, err = utils.ToDuration("soon")
+	require.ErrorIs(t, err, configerrors.ErrNotDuration)
+}
+
+func TestToDurationSlice(t *testing.T) {
+	t.Parallel()
+
+	want := []time.Duration{time.Second, 5 * time.Second, 30 * time.Second}
+
+	ds, err := utils.ToDurationSlice([]any{"1s", "5s", "30s"})
+	require.NoError(t, err)
+	require.Equal(t, want, ds)
+
+	ds, err = utils.ToDurationSlice([]string{"1s", "5s", "30s"})
+	require.NoError(t, err)
+	require.Equal(t, want, ds)
+
+	ds, err = utils.ToDurationSlice(want)
+	require.NoError(t, err)
+	require.Equal(t, want, ds)
+
+	_, err = utils.ToDurationSlice([]any{"1s", "later"})
+	require.ErrorIs(t, err, configerrors.ErrNotDurationSlice)
+	require.ErrorIs(t, err, configerrors.ErrNotDuration)
+	require.Contains(t, err.Error(), "element 1")
+
+	_, err = utils.ToDurationSlice("1s")
+	require.ErrorIs(t, err, configerrors.ErrNotDurationSlice)
+}
+
+func FuzzNormalizeEnvKey(f *testing.F) {
+	for _, seed := range []string{"APP_NAME", "__APP__NAME_", "A.B", "_", "", "DB__MAX_CONNS", "x..y__z"} {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, key string) {
+		out := utils.NormalizeEnvKey(key)
+		if out == "" {
+			return
+		}
+
+		for _, segment := range strings.Split(out, ".") {
+			if segment == "" {
+				t.Fatalf("NormalizeEnvKey(%q) = %q contains an empty segment", key, out)
+			}
+		}
+
+		if strings.Contains(out, "_") {
+			t.Fatalf("NormalizeEnvKey(%q) = %q contains a separator", key, out)
+		}
+
+		// Converting the result back to env form and normalizing again is stable.
+		if again := utils.NormalizeEnvKey(strings.ReplaceAll(out, ".", "_")); again != out {
+			t.Fatalf("round-trip of %q: got %q, want %q", key, again, out)
+		}
+	})
+}
+
+func TestNormalizeMap_InterfaceKeyedMaps(t *testing.T) {
+	t.Parallel()
+
+	input := map[interface{}]interface{}{
+		"db": map[interface{}]interface{}{
+			"host":  "localhost",
+			"ports": []any{map[interface{}]interface{}{"n": 5432}},
+		},
+		1: "one",
+	}
+
+	require.Equal(t, map[string]any{
+		"db": map[string]any{
+			"host":  "localhost",
+			"ports": []any{map[string]any{"n": 5432}},
+		},
+		"1": "one",
+	}, utils.NormalizeMap(input))
+	require.Equal(t, "scalar", utils.NormalizeMap("scalar"))
+
+	m, err := utils.ToMap(input)
+	require.NoError(t, err)
+	require.Equal(t, map[string]any{"host": "localhost", "ports": []any{map[string]any{"n": 5432}}}, m["db"])
+}
+
+func TestToStringSlice_SplitsStrings(t *testing.T) {
+	t.Parallel()
+
+	ss, err := utils.ToStringSliceDelim("admin, user ,ops", "")
+	require.NoError(t, err)
+	require.Equal(t, []string{"admin", "user", "ops"}, ss)
+
+	ss, err = utils.ToStringSliceDelim("", ",")
+	require.NoError(t, err)
+	require.Equal(t, []string{}, ss)
+
+	ss, err = utils.ToStringSliceDelim("solo", ",")
+	require.NoError(t, err)
+	require.Equal(t, []string{"solo"}, ss)
+
+	ss, err = utils.ToStringSliceDelim([]any{"x", "y"}, ",")
+	require.NoError(t, err)
+	require.Equal(t, []string{"x", "y"}, ss)
+
+	// Splitting is opt-in: ToStringSlice still rejects a plain string.
+	_, err = utils.ToStringSlice("admin,user")
+	require.ErrorIs(t, err, configerrors.ErrNotStringSlice)
+
+	ss, err = utils.ToStringSliceDelim("a; b;;c ", ";")
+	require.NoError(t, err)
+	require.Equal(t, []string{"a", "b", "c"}, ss)
+
+	require.Equal(t, []string{}, utils.SplitList("  ", ""))
+}
+
+func TestUnmarshalJSON_ExactNumbers(t *testing.T) {
+	t.Parallel()
+
+	got, err := utils.UnmarshalJSON([]byte(`{"id": 9007199254740993, "max": 18446744073709551615,
+		"ratio": 0.5, "exp": 1e3, "list": [1, 2.5], "nested": {"n": -7}}`))
+	require.NoError(t, err)
+	require.Equal(t, map[string]any{
+		"id":     int64(9007199254740993),
+		"max":    uint64(18446744073709551615),
+		"ratio":  0.5,
+		"exp":    1000.0,
+		"list":   []any{int64(1), 2.5},
+		"nested": map[string]any{"n": int64(-7)},
+	}, got)
+
+	_, err = utils.UnmarshalJSON([]byte(`{"a": 1} {"b": 2}`))
+	require.Error(t, err)
+
+	for _, trailing := range []string{`{"a": 1} xyz`, `{"a": 1}}`, `{"a": 1}]`} {
+		_, err = utils.UnmarshalJSON([]byte(trailing))
+		require.Error(t, err, trailing)
+	}
+
+	got, err = utils.UnmarshalJSON([]byte("{\"a\": 1}\n\t "))
+	require.NoError(t, err)
+	require.Equal(t, map[string]any{"a": int64(1)}, got)
+
+	_, err = utils.UnmarshalJSON([]byte(`{"a": }`))
+	var syntaxErr *json.SyntaxError
+	require.ErrorAs(t, err, &syntaxErr)
+}
+
+func TestNumericConverters_JSONNumber(t *testing.T) {
+	t.Parallel()
+	big := json.Number("9876543210")
+
+	i64, err := utils.ToInt64(big)
+	require.NoError(t, err)
+	require.Equal(t, int64(9876543210), i64)
+
+	f64, err := utils.ToFloat64(big)
+	require.NoError(t, err)
+	require.InDelta(t, 9876543210.0, f64, 0)
+
+	u64, err := utils.ToUint64(json.Number("18446744073709551615"))
+	require.NoError(t, err)
+	require.Equal(t, uint64(18446744073709551615), u64)
+
+	i, err := utils.ToInt(json.Number("42"))
+	require.NoError(t, err)
+	require.Equal(t, 42, i)
+
+	u, err := utils.ToUint(json.Number("7"))
+	require.NoError(t, err)
+	require.Equal(t, uint(7), u)
+
+	f32, err := utils.ToFloat32(json.Number("1.5"))
+	require.NoError(t, err)
+	require.InDelta(t, float32(1.5), f32, 0)
+
+	_, err = utils.ToInt32(big)
+	require.ErrorIs(t, err, configerrors.ErrNotInt32)
+	_, err = utils.ToUint32(big)
+	require.ErrorIs(t, err, configerrors.ErrNotUint32)
+	_, err = utils.ToUint(json.Number("-1"))
+	require.ErrorIs(t, err, configerrors.ErrNotUint)
+	_, err = utils.ToInt64(json.Number("1.5"))
+	require.ErrorIs(t, err, configerrors.ErrNotInt64)
+	_, err = utils.ToFloat32(json.Number("1e300"))
+	require.ErrorIs(t, err, configerrors.ErrNotFloat32)
+}
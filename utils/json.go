@@ -0,0 +1,69 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// UnmarshalJSON decodes the JSON object in data into a map like json.Unmarshal,
+// but keeps integers exact: numbers without a fraction or exponent become
+// int64 (or uint64 above math.MaxInt64) instead of float64, which cannot
+// represent integers beyond 2^53. Other numbers become float64.
+func UnmarshalJSON(data []byte) (map[string]any, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var result map[string]any
+	if err := decoder.Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode JSON: %w", err)
+	}
+
+	// Reject trailing content after the object, as json.Unmarshal does: only
+	// whitespace may follow, so anything but io.EOF (another value or invalid
+	// input alike) is an error.
+	var extra any
+	if err := decoder.Decode(&extra); !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("failed to decode JSON: unexpected content after top-level value")
+	}
+
+	normalized, _ := exactNumbers(result).(map[string]any)
+
+	return normalized, nil
+}
+
+// exactNumbers replaces every json.Number in v with an int64, uint64 or
+// float64, descending into maps and slices.
+func exactNumbers(v any) any {
+	switch value := v.(type) {
+	case map[string]any:
+		for key, elem := range value {
+			value[key] = exactNumbers(elem)
+		}
+
+		return value
+	case []any:
+		for i, elem := range value {
+			value[i] = exactNumbers(elem)
+		}
+
+		return value
+	case json.Number:
+		if i, err := value.Int64(); err == nil {
+			return i
+		}
+
+		if u, err := strconv.ParseUint(value.String(), 10, 64); err == nil {
+			return u
+		}
+
+		f, _ := value.Float64()
+
+		return f
+	default:
+		return v
+	}
+}
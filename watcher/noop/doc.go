@@ -0,0 +1,4 @@
+// Package noop provides a contract.Watcher that never watches anything, for
+// environments such as serverless or containers where configuration files do
+// not change at runtime.
+package noop
@@ -0,0 +1,28 @@
+package noop
+
+import "github.com/next-trace/scg-config/contract"
+
+// Watcher is a contract.Watcher whose methods do nothing. It allocates no OS
+// resources and never invokes callbacks.
+type Watcher struct{}
+
+// New creates a no-op Watcher.
+func New() *Watcher {
+	return &Watcher{}
+}
+
+// AddFile accepts any path without touching the filesystem and returns nil.
+func (w *Watcher) AddFile(_ string, _ func()) error {
+	return nil
+}
+
+// Watch does nothing.
+func (w *Watcher) Watch(_ func()) {}
+
+// Close does nothing and returns nil.
+func (w *Watcher) Close() error {
+	return nil
+}
+
+// Compile time checks for interface.
+var _ contract.Watcher = (*Watcher)(nil)
@@ -0,0 +1,27 @@
+package noop_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/next-trace/scg-config/config"
+	"github.com/next-trace/scg-config/watcher/noop"
+)
+
+func TestNoopWatcher_StartWatchingWithoutFilesystem(t *testing.T) {
+	t.Parallel()
+
+	cfg := config.New(config.WithWatcher(noop.New()))
+
+	// The path does not exist; the noop watcher never looks at it.
+	require.NoError(t, cfg.StartWatching(filepath.Join(t.TempDir(), "missing.yaml")))
+
+	called := false
+	cfg.Watcher().Watch(func() { called = true })
+	require.False(t, called)
+
+	require.NoError(t, cfg.Close())
+	require.NoError(t, cfg.Watcher().Close())
+}
@@ -0,0 +1,4 @@
+// Package poll provides a polling implementation of contract.Watcher for
+// filesystems where fsnotify events are unreliable, such as NFS or some
+// container volume mounts.
+package poll
@@ -0,0 +1,160 @@
+// Package poll provides a polling file watcher for configuration files.
+package poll
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/next-trace/scg-config/contract"
+)
+
+// DefaultInterval is the polling interval used when a non-positive interval is given.
+const DefaultInterval = time.Second
+
+// watchedFile tracks the last observed state of a file and its callback.
+type watchedFile struct {
+	info     os.FileInfo
+	callback func()
+}
+
+// Watcher periodically stats watched files and invokes callbacks when their
+// modification time, size or identity (e.g. after an atomic replace) changes.
+type Watcher struct {
+	interval time.Duration
+	files    map[string]*watchedFile
+	done     chan struct{}
+	mu       sync.Mutex
+	wg       sync.WaitGroup
+	started  bool
+}
+
+// NewWatcher creates a new polling Watcher that checks files every interval.
+func NewWatcher(interval time.Duration) *Watcher {
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+
+	return &Watcher{
+		interval: interval,
+		files:    make(map[string]*watchedFile),
+		done:     nil,
+		mu:       sync.Mutex{},
+		wg:       sync.WaitGroup{},
+		started:  false,
+	}
+}
+
+// AddFile adds a file to the watcher and registers its callback. The file must
+// exist; its current state is recorded as the baseline for change detection.
+func (w *Watcher) AddFile(path string, callback func()) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("failed to add file to watcher: %w", err)
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.files[path] = &watchedFile{info: info, callback: callback}
+	w.startLocked()
+
+	return nil
+}
+
+// Watch replaces the callback of every watched file and starts polling if not
+// already running.
+func (w *Watcher) Watch(callback func()) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for _, file := range w.files {
+		file.callback = callback
+	}
+
+	w.startLocked()
+}
+
+// startLocked starts the polling goroutine if not already started.
+// Assumes the caller holds w.mu.
+func (w *Watcher) startLocked() {
+	if w.started {
+		return
+	}
+
+	w.started = true
+	w.done = make(chan struct{})
+	w.wg.Add(1)
+
+	go w.run(w.done)
+}
+
+// run polls watched files until done is closed.
+func (w *Watcher) run(done chan struct{}) {
+	defer w.wg.Done()
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+			for _, callback := range w.poll() {
+				callback()
+			}
+		}
+	}
+}
+
+// poll stats every watched file and returns the callbacks of files that changed.
+// Files that temporarily cannot be stat'ed (e.g. mid-replace) keep their
+// previous state and are checked again on the next tick.
+func (w *Watcher) poll() []func() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	var callbacks []func()
+
+	for path, file := range w.files {
+		info, err := os.Stat(path)
+		if err != nil || !changed(file.info, info) {
+			continue
+		}
+
+		file.info = info
+
+		if file.callback != nil {
+			callbacks = append(callbacks, file.callback)
+		}
+	}
+
+	return callbacks
+}
+
+// changed reports whether a file differs between two observations.
+func changed(prev, cur os.FileInfo) bool {
+	return !prev.ModTime().Equal(cur.ModTime()) || prev.Size() != cur.Size() || !os.SameFile(prev, cur)
+}
+
+// Close stops polling and forgets all watched files. It is safe to call more than once.
+func (w *Watcher) Close() error {
+	w.mu.Lock()
+
+	if w.started {
+		close(w.done)
+		w.started = false
+	}
+
+	w.files = make(map[string]*watchedFile)
+	w.mu.Unlock()
+
+	w.wg.Wait()
+
+	return nil
+}
+
+// Compile time checks for interface.
+var _ contract.Watcher = (*Watcher)(nil)
@@ -0,0 +1,137 @@
+package poll_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/next-trace/scg-config/config"
+	"github.com/next-trace/scg-config/watcher/poll"
+)
+
+const testInterval = 20 * time.Millisecond
+
+func notify(ch chan struct{}) func() {
+	return func() {
+		select {
+		case ch <- struct{}{}:
+		default:
+		}
+	}
+}
+
+func waitCalled(t *testing.T, ch chan struct{}) {
+	t.Helper()
+
+	select {
+	case <-ch:
+	case <-time.After(2 * time.Second):
+		t.Fatal("callback was not called within timeout")
+	}
+}
+
+func TestPollWatcher_DetectsContentChange(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "app.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("a: 1"), 0o600))
+
+	w := poll.NewWatcher(testInterval)
+	defer func() { _ = w.Close() }()
+
+	called := make(chan struct{}, 1)
+	require.NoError(t, w.AddFile(path, notify(called)))
+
+	require.NoError(t, os.WriteFile(path, []byte("a: 22"), 0o600))
+	waitCalled(t, called)
+}
+
+func TestPollWatcher_DetectsAtomicReplace(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("a: 1"), 0o600))
+
+	w := poll.NewWatcher(testInterval)
+	defer func() { _ = w.Close() }()
+
+	called := make(chan struct{}, 1)
+	require.NoError(t, w.AddFile(path, notify(called)))
+
+	tmp := filepath.Join(dir, "app.yaml.tmp")
+	require.NoError(t, os.WriteFile(tmp, []byte("a: 2"), 0o600))
+	require.NoError(t, os.Rename(tmp, path))
+	waitCalled(t, called)
+}
+
+func TestPollWatcher_NoChange_NoCallback(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "app.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("a: 1"), 0o600))
+
+	w := poll.NewWatcher(testInterval)
+	defer func() { _ = w.Close() }()
+
+	called := make(chan struct{}, 1)
+	require.NoError(t, w.AddFile(path, notify(called)))
+
+	select {
+	case <-called:
+		t.Fatal("callback should not be called for an unchanged file")
+	case <-time.After(10 * testInterval):
+	}
+}
+
+func TestPollWatcher_WatchOverridesCallbacks(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "app.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("a: 1"), 0o600))
+
+	w := poll.NewWatcher(testInterval)
+	defer func() { _ = w.Close() }()
+
+	chA := make(chan struct{}, 1)
+	chB := make(chan struct{}, 1)
+	require.NoError(t, w.AddFile(path, notify(chA)))
+	w.Watch(notify(chB))
+
+	require.NoError(t, os.WriteFile(path, []byte("a: 22"), 0o600))
+	waitCalled(t, chB)
+
+	select {
+	case <-chA:
+		t.Fatal("old callback should not be called after Watch override")
+	default:
+	}
+}
+
+func TestPollWatcher_MissingFile_Error(t *testing.T) {
+	t.Parallel()
+	w := poll.NewWatcher(0)
+	require.Error(t, w.AddFile(filepath.Join(t.TempDir(), "missing.yaml"), func() {}))
+	require.NoError(t, w.Close())
+}
+
+func TestPollWatcher_CloseTwice_IsIdempotent(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "app.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("a: 1"), 0o600))
+
+	w := poll.NewWatcher(testInterval)
+	require.NoError(t, w.AddFile(path, func() {}))
+	require.NoError(t, w.Close())
+	require.NoError(t, w.Close())
+}
+
+func TestPollWatcher_AsConfigWatcher(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "app.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("a: 1"), 0o600))
+
+	cfg := config.New(config.WithWatcher(poll.NewWatcher(testInterval)))
+	defer func() { _ = cfg.Close() }()
+
+	require.NoError(t, cfg.StartWatching(path))
+}
@@ -2,14 +2,38 @@
 package watcher
 
 import (
+	"context"
+	"crypto/sha256"
 	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
 	"sync"
+	"time"
 
 	"github.com/fsnotify/fsnotify"
 
 	"github.com/next-trace/scg-config/contract"
 )
 
+// Event describes a change to a watched file.
+type Event struct {
+	// Path is the watched path as registered, even when the change was
+	// observed through its directory (e.g. a symlink swap).
+	Path string
+	// Op is the operation: fsnotify.Write or fsnotify.Create when the content
+	// changed, fsnotify.Remove or fsnotify.Rename when the file went away.
+	Op fsnotify.Op
+}
+
+// Recreation polling after a watched file is removed or renamed away.
+const (
+	// rewatchAttempts bounds how often a removed file is looked for again.
+	rewatchAttempts = 50
+	// rewatchInterval is the delay between two lookups of a removed file.
+	rewatchInterval = 100 * time.Millisecond
+)
+
 // Watcher provides file watching capabilities for configuration files.
 type Watcher struct {
 	config   contract.Config
@@ -18,8 +42,11 @@ type Watcher struct {
 	mu       sync.Mutex
 	eventMux sync.Mutex
 	wg       sync.WaitGroup
-	files    map[string]func()
+	files    map[string]func(Event)
+	hashes   map[string][sha256.Size]byte
 	started  bool
+	paused   bool
+	pending  *Event
 }
 
 // NewWatcher creates a new Watcher instance.
@@ -27,7 +54,8 @@ func NewWatcher(config contract.Config) *Watcher {
 	return &Watcher{
 		config:   config,
 		done:     make(chan struct{}),
-		files:    make(map[string]func()),
+		files:    make(map[string]func(Event)),
+		hashes:   make(map[string][sha256.Size]byte),
 		watcher:  nil,
 		started:  false,
 		mu:       sync.Mutex{},
@@ -36,11 +64,65 @@ func NewWatcher(config contract.Config) *Watcher {
 	}
 }
 
-// AddFile adds a file to the watcher and registers its callback.
+// AddFile adds a file to the watcher and registers its callback. The callback
+// runs when the file's content changes; removals are not reported to it. An
+// emptied file is never reported, since it cannot be told apart from a save in
+// progress; clear a config file by writing an empty document such as "{}".
 func (w *Watcher) AddFile(path string, callback func()) error {
+	return w.AddFileWithEvent(path, ignoreEvent(callback))
+}
+
+// AddFileWithEvent adds a file to the watcher and registers a callback that
+// receives the details of each change, including removal of the file.
+func (w *Watcher) AddFileWithEvent(path string, callback func(Event)) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if err := w.addFileLocked(path, callback); err != nil {
+		return err
+	}
+
+	w.startLocked()
+
+	return nil
+}
+
+// AddFiles adds every path to the watcher with a shared callback. If any path
+// cannot be added, the watches added by this call are removed again (and
+// callbacks of already watched paths restored) so that the watcher is left as
+// it was before the call.
+func (w *Watcher) AddFiles(paths []string, callback func()) error {
 	w.mu.Lock()
 	defer w.mu.Unlock()
 
+	previous := make(map[string]func(Event), len(paths))
+
+	var added []string
+
+	for _, path := range paths {
+		if cb, ok := w.files[path]; ok {
+			if _, seen := previous[path]; !seen {
+				previous[path] = cb
+			}
+		}
+
+		if err := w.addFileLocked(path, ignoreEvent(callback)); err != nil {
+			w.rollbackLocked(added, previous)
+
+			return err
+		}
+
+		added = append(added, path)
+	}
+
+	w.startLocked()
+
+	return nil
+}
+
+// addFileLocked registers path with fsnotify and records its callback and
+// baseline hash. Assumes the caller holds w.mu.
+func (w *Watcher) addFileLocked(path string, callback func(Event)) error {
 	if w.watcher == nil {
 		newWatcher, err := fsnotify.NewWatcher()
 		if err != nil {
@@ -54,52 +136,132 @@ func (w *Watcher) AddFile(path string, callback func()) error {
 		return fmt.Errorf("failed to add file to watcher: %w", err)
 	}
 
+	// Also watch the containing directory: when path is a symlink whose target
+	// is swapped atomically (e.g. a Kubernetes ConfigMap mount), the watch on
+	// the old target goes stale and only the directory sees the swap.
+	if err := w.watcher.Add(filepath.Dir(path)); err != nil {
+		_ = w.watcher.Remove(path)
+
+		return fmt.Errorf("failed to add directory to watcher: %w", err)
+	}
+
 	w.files[path] = callback
-	w.startLocked()
+
+	// Record the current content hash as the baseline so that events which do
+	// not change the content (e.g. touch) do not trigger callbacks.
+	if sum, err := hashFile(path); err == nil {
+		w.hashes[path] = sum
+	}
 
 	return nil
 }
 
+// rollbackLocked undoes the registration of added paths. Paths that were
+// already watched before keep their watch and get their previous callback
+// back. Assumes the caller holds w.mu.
+func (w *Watcher) rollbackLocked(added []string, previous map[string]func(Event)) {
+	for _, path := range added {
+		if cb, ok := previous[path]; ok {
+			w.files[path] = cb
+
+			continue
+		}
+
+		w.removeLocked(path)
+	}
+}
+
+// removeLocked stops watching path, and its directory when no other watched
+// file lives there. Assumes the caller holds w.mu.
+func (w *Watcher) removeLocked(path string) {
+	_ = w.watcher.Remove(path)
+
+	delete(w.files, path)
+	delete(w.hashes, path)
+
+	dir := filepath.Dir(path)
+	for other := range w.files {
+		if filepath.Dir(other) == dir {
+			return
+		}
+	}
+
+	_ = w.watcher.Remove(dir)
+}
+
 // Watch starts the watcher loop if not already running.
 func (w *Watcher) Watch(callback func()) {
 	w.mu.Lock()
 	defer w.mu.Unlock()
 
 	for path := range w.files {
-		w.files[path] = callback
+		w.files[path] = ignoreEvent(callback)
 	}
 
 	w.startLocked()
 }
 
+// ignoreEvent adapts a plain callback to func(Event). The adapted callback is
+// invoked for content changes only, so removals never trigger a reload.
+func ignoreEvent(callback func()) func(Event) {
+	if callback == nil {
+		return nil
+	}
+
+	return func(event Event) {
+		if event.Op.Has(fsnotify.Write) || event.Op.Has(fsnotify.Create) {
+			callback()
+		}
+	}
+}
+
+// WatchContext behaves like Watch and additionally closes the watcher once
+// ctx is done, releasing the underlying fsnotify resources.
+func (w *Watcher) WatchContext(ctx context.Context, callback func()) {
+	w.Watch(callback)
+
+	w.mu.Lock()
+	done := w.done
+	w.mu.Unlock()
+
+	go func() {
+		select {
+		case <-ctx.Done():
+			_ = w.Close()
+		case <-done:
+		}
+	}()
+}
+
 // startLocked starts the watcher goroutine if not already started.
+// It is a no-op until a file has been added and fsnotify is initialised.
 // Assumes the caller holds w.mu.
 func (w *Watcher) startLocked() {
-	if w.started {
+	if w.started || w.watcher == nil {
 		return
 	}
 
 	w.started = true
 	w.wg.Add(1)
 
-	go w.run()
+	go w.run(w.watcher, w.done)
 }
 
 // run is the goroutine that dispatches file system events.
-func (w *Watcher) run() {
+func (w *Watcher) run(fsWatcher *fsnotify.Watcher, done chan struct{}) {
 	defer w.wg.Done()
 
 	for {
 		select {
-		case <-w.done:
+		case <-done:
 			return
-		case event, ok := <-w.watcher.Events:
+		case event, ok := <-fsWatcher.Events:
 			if !ok {
 				return
 			}
 
 			w.handleEvent(event)
-		case err, ok := <-w.watcher.Errors:
+		case err, ok := <-fsWatcher.Errors:
 			// Check if the error channel has been closed
 			if !ok {
 				return
@@ -112,42 +274,264 @@ func (w *Watcher) run() {
 	}
 }
 
-// handleEvent is called for every fsnotify event.
+// handleEvent is called for every fsnotify event. Events on a watched file
+// concern that file; events on other entries of a watched directory (such as
+// a symlink swap) concern every watched file in that directory. Callbacks only
+// fire for files whose content actually changed or that were removed.
 func (w *Watcher) handleEvent(event fsnotify.Event) {
 	w.eventMux.Lock()
 	defer w.eventMux.Unlock()
 
-	if event.Op&fsnotify.Write == fsnotify.Write {
-		if reloadable, ok := w.config.(interface{ ReloadConfig() }); ok {
-			reloadable.ReloadConfig()
+	for _, path := range w.affectedPaths(event.Name) {
+		change, ok := w.classify(path, event)
+		if !ok {
+			continue
 		}
 
 		w.mu.Lock()
-		cb := w.files[event.Name]
+		if w.paused {
+			w.pending = &change
+			w.mu.Unlock()
+
+			continue
+		}
+
+		cb := w.files[path]
 		w.mu.Unlock()
 
-		if cb != nil {
-			cb()
+		w.dispatch(cb, change)
+	}
+}
+
+// classify turns event into the Event reported for the watched path, or
+// reports false when nothing relevant happened to the path.
+func (w *Watcher) classify(path string, event fsnotify.Event) (Event, bool) {
+	direct := path == event.Name
+
+	if direct && event.Op&(fsnotify.Remove|fsnotify.Rename) != 0 {
+		if _, err := os.Stat(path); err != nil {
+			// Report a removal once, even if both the file and its directory
+			// watch deliver it.
+			if !w.forgetHash(path) {
+				return Event{}, false
+			}
+
+			w.startRewatch(path)
+
+			return Event{Path: path, Op: event.Op}, true
 		}
 	}
+
+	// A direct write to a file that cannot be read is still reported; other
+	// events (e.g. the removal half of a swap) require readable new content.
+	if !w.contentChanged(path, direct && event.Op.Has(fsnotify.Write)) {
+		return Event{}, false
+	}
+
+	if direct && event.Op&(fsnotify.Write|fsnotify.Create) != 0 {
+		return Event{Path: path, Op: event.Op}, true
+	}
+
+	return Event{Path: path, Op: fsnotify.Write}, true
 }
 
-// Close stops the watcher.
-func (w *Watcher) Close() error {
+// forgetHash drops the recorded hash of path, reporting whether one existed.
+func (w *Watcher) forgetHash(path string) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	_, ok := w.hashes[path]
+	delete(w.hashes, path)
+
+	return ok
+}
+
+// startRewatch starts polling for the recreation of the removed path.
+func (w *Watcher) startRewatch(path string) {
 	w.mu.Lock()
 	defer w.mu.Unlock()
 
-	if w.watcher != nil {
-		close(w.done)
-		w.wg.Wait()
-		err := w.watcher.Close()
-		w.watcher = nil
-		w.files = make(map[string]func())
-		w.started = false
+	if w.watcher == nil {
+		return
+	}
+
+	w.wg.Add(1)
+
+	go w.rewatch(path, w.watcher, w.done)
+}
+
+// rewatch waits for path to be recreated, as editors such as Vim do when they
+// delete the file and write a new one, and then watches the new file: fsnotify
+// drops the watch together with the removed inode. The recreation is handled
+// like a create event, so the callback fires once if the content changed and
+// the directory watch has not reported it already. Polling gives up after
+// rewatchAttempts tries or when the watcher is closed.
+func (w *Watcher) rewatch(path string, fsWatcher *fsnotify.Watcher, done chan struct{}) {
+	defer w.wg.Done()
+
+	ticker := time.NewTicker(rewatchInterval)
+	defer ticker.Stop()
+
+	for range rewatchAttempts {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+		}
+
+		if _, err := os.Stat(path); err != nil {
+			continue
+		}
+
+		w.mu.Lock()
+		if _, ok := w.files[path]; !ok || w.watcher != fsWatcher {
+			w.mu.Unlock()
+
+			return
+		}
+
+		err := fsWatcher.Add(path)
+		w.mu.Unlock()
 
 		if err != nil {
-			return fmt.Errorf("error closing fsnotify watcher: %w", err)
+			continue
 		}
+
+		w.handleEvent(fsnotify.Event{Name: path, Op: fsnotify.Create})
+
+		return
+	}
+}
+
+// affectedPaths returns the watched paths an event on name may concern.
+func (w *Watcher) affectedPaths(name string) []string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if _, ok := w.files[name]; ok {
+		return []string{name}
+	}
+
+	dir := filepath.Dir(name)
+
+	var paths []string
+
+	for path := range w.files {
+		if filepath.Dir(path) == dir {
+			paths = append(paths, path)
+		}
+	}
+
+	sort.Strings(paths)
+
+	return paths
+}
+
+// dispatch asks the config to reload, if supported, and then invokes cb.
+func (w *Watcher) dispatch(cb func(Event), event Event) {
+	if reloadable, ok := w.config.(interface{ ReloadConfig() }); ok {
+		reloadable.ReloadConfig()
+	}
+
+	if cb != nil {
+		cb(event)
+	}
+}
+
+// Pause suspends reload callbacks. Changes detected while paused are not
+// lost: they are coalesced and reported by a single callback on Resume.
+func (w *Watcher) Pause() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.paused = true
+}
+
+// Resume re-enables reload callbacks. If any watched file changed while
+// paused, one callback fires: the one registered for the file that changed
+// last, which is the shared callback when files are watched via Watch.
+func (w *Watcher) Resume() {
+	w.eventMux.Lock()
+	defer w.eventMux.Unlock()
+
+	w.mu.Lock()
+	pending := w.pending
+	w.paused = false
+	w.pending = nil
+
+	var cb func(Event)
+	if pending != nil {
+		cb = w.files[pending.Path]
+	}
+	w.mu.Unlock()
+
+	if pending != nil {
+		w.dispatch(cb, *pending)
+	}
+}
+
+// contentChanged reports whether the content of path differs from the last
+// recorded hash, updating the recorded hash when it did. Files that cannot be
+// read are treated as changed when unreadableChanged is set. An empty file is
+// treated as a save in progress (writers truncate before writing) and is not
+// reported; the write that follows is compared against the previous content.
+func (w *Watcher) contentChanged(path string, unreadableChanged bool) bool {
+	sum, err := hashFile(path)
+	if err != nil {
+		return unreadableChanged
+	}
+
+	if sum == sha256.Sum256(nil) {
+		return false
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if prev, ok := w.hashes[path]; ok && prev == sum {
+		return false
+	}
+
+	w.hashes[path] = sum
+
+	return true
+}
+
+// hashFile returns the SHA-256 checksum of the file content at path.
+func hashFile(path string) ([sha256.Size]byte, error) {
+	// #nosec G304 -- path is a file explicitly registered for watching by the caller.
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return [sha256.Size]byte{}, fmt.Errorf("failed to read watched file: %w", err)
+	}
+
+	return sha256.Sum256(data), nil
+}
+
+// Close stops the watcher. The event loop is stopped without holding w.mu so
+// that an event being handled concurrently can finish.
+func (w *Watcher) Close() error {
+	w.mu.Lock()
+
+	fsWatcher := w.watcher
+	if fsWatcher == nil {
+		w.mu.Unlock()
+
+		return nil
+	}
+
+	close(w.done)
+	w.watcher = nil
+	w.files = make(map[string]func(Event))
+	w.hashes = make(map[string][sha256.Size]byte)
+	w.started = false
+	w.pending = nil
+	w.mu.Unlock()
+
+	w.wg.Wait()
+
+	if err := fsWatcher.Close(); err != nil {
+		return fmt.Errorf("error closing fsnotify watcher: %w", err)
 	}
 
 	return nil
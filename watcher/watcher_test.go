@@ -1,11 +1,15 @@
 package watcher_test
 
 import (
+	"context"
+	"fmt"
 	"os"
 	"path/filepath"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/fsnotify/fsnotify"
 	"github.com/stretchr/testify/require"
 
 	"github.com/next-trace/scg-config/config"
@@ -128,3 +132,356 @@ func TestWatcher_SetGetConfig(t *testing.T) {
 	require.Equal(t, cfg, w.GetConfig())
 	_ = w.Close()
 }
+
+func TestWatcher_WatchContext_ClosesOnCancel(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "ctx.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("a: 1"), 0o600))
+
+	w := watcher.NewWatcher(nil)
+	defer func() { _ = w.Close() }()
+
+	called := make(chan struct{}, 1)
+	require.NoError(t, w.AddFile(path, func() {}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	w.WatchContext(ctx, func() {
+		select {
+		case called <- struct{}{}:
+		default:
+		}
+	})
+	cancel()
+
+	// Give the cancellation goroutine time to close the watcher.
+	time.Sleep(100 * time.Millisecond)
+
+	require.NoError(t, os.WriteFile(path, []byte("a: 2"), 0o600))
+
+	select {
+	case <-called:
+		t.Fatal("callback should not be called after context cancellation")
+	case <-time.After(300 * time.Millisecond):
+		// ok
+	}
+}
+
+func TestWatcher_Watch_NoFiles_DoesNotStart(t *testing.T) {
+	t.Parallel()
+	w := watcher.NewWatcher(nil)
+	w.Watch(func() {})
+	require.NoError(t, w.Close())
+}
+
+func TestWatcher_IdenticalContent_NoCallback(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "same.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("a: 1"), 0o600))
+
+	w := watcher.NewWatcher(nil)
+	defer func() { _ = w.Close() }()
+
+	called := make(chan struct{}, 1)
+	require.NoError(t, w.AddFile(path, func() {
+		select {
+		case called <- struct{}{}:
+		default:
+		}
+	}))
+
+	// Rewrite the exact same bytes: a Write event fires but content is unchanged.
+	require.NoError(t, os.WriteFile(path, []byte("a: 1"), 0o600))
+
+	select {
+	case <-called:
+		t.Fatal("callback should not be called when content is unchanged")
+	case <-time.After(300 * time.Millisecond):
+	}
+
+	// A real change still triggers the callback.
+	require.NoError(t, os.WriteFile(path, []byte("a: 2"), 0o600))
+
+	select {
+	case <-called:
+	case <-time.After(2 * time.Second):
+		t.Fatal("callback was not called after content change")
+	}
+}
+
+func TestWatcher_EmptiedFile_NoCallback(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "empty.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("a: 1"), 0o600))
+
+	w := watcher.NewWatcher(nil)
+	defer func() { _ = w.Close() }()
+
+	called := make(chan struct{}, 1)
+	require.NoError(t, w.AddFile(path, func() {
+		select {
+		case called <- struct{}{}:
+		default:
+		}
+	}))
+
+	// Truncating the file looks like a save in progress and is not reported,
+	// even when it stays empty.
+	require.NoError(t, os.WriteFile(path, nil, 0o600))
+
+	select {
+	case <-called:
+		t.Fatal("callback should not be called for an empty file")
+	case <-time.After(300 * time.Millisecond):
+	}
+
+	// Restoring the previous content is not a change either.
+	require.NoError(t, os.WriteFile(path, []byte("a: 1"), 0o600))
+
+	select {
+	case <-called:
+		t.Fatal("callback should not be called when content is restored")
+	case <-time.After(300 * time.Millisecond):
+	}
+
+	require.NoError(t, os.WriteFile(path, []byte("a: 2"), 0o600))
+
+	select {
+	case <-called:
+	case <-time.After(2 * time.Second):
+		t.Fatal("callback was not called after content change")
+	}
+}
+
+func TestWatcher_AddFiles(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	first := filepath.Join(dir, "a.yaml")
+	second := filepath.Join(dir, "b.yaml")
+	require.NoError(t, os.WriteFile(first, []byte("a: 1"), 0o600))
+	require.NoError(t, os.WriteFile(second, []byte("b: 1"), 0o600))
+
+	w := watcher.NewWatcher(nil)
+	defer func() { _ = w.Close() }()
+
+	called := make(chan string, 2)
+	require.NoError(t, w.AddFiles([]string{first, second}, func() { called <- "shared" }))
+
+	require.NoError(t, os.WriteFile(second, []byte("b: 2"), 0o600))
+
+	select {
+	case <-called:
+	case <-time.After(2 * time.Second):
+		t.Fatal("shared callback was not called")
+	}
+}
+
+func TestWatcher_AddFiles_RollsBackOnError(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	valid := filepath.Join(dir, "valid.yaml")
+	require.NoError(t, os.WriteFile(valid, []byte("a: 1"), 0o600))
+
+	w := watcher.NewWatcher(nil)
+	defer func() { _ = w.Close() }()
+
+	called := make(chan struct{}, 1)
+	notify := func() {
+		select {
+		case called <- struct{}{}:
+		default:
+		}
+	}
+
+	err := w.AddFiles([]string{valid, filepath.Join(dir, "missing.yaml")}, notify)
+	require.Error(t, err)
+
+	// Start the loop: if valid.yaml were still watched, its change would fire.
+	w.Watch(notify)
+	require.NoError(t, os.WriteFile(valid, []byte("a: 2"), 0o600))
+
+	select {
+	case <-called:
+		t.Fatal("no file should remain watched after a failed AddFiles")
+	case <-time.After(300 * time.Millisecond):
+	}
+}
+
+func TestWatcher_PauseResume_CoalescesChanges(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "bulk.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("v: 0"), 0o600))
+
+	w := watcher.NewWatcher(nil)
+	defer func() { _ = w.Close() }()
+
+	var calls atomic.Int32
+
+	require.NoError(t, w.AddFile(path, func() { calls.Add(1) }))
+
+	w.Pause()
+
+	for i := 1; i <= 3; i++ {
+		require.NoError(t, os.WriteFile(path, []byte(fmt.Sprintf("v: %d", i)), 0o600))
+		time.Sleep(50 * time.Millisecond)
+	}
+
+	time.Sleep(200 * time.Millisecond)
+	require.Equal(t, int32(0), calls.Load(), "no callbacks while paused")
+
+	w.Resume()
+	require.Equal(t, int32(1), calls.Load(), "one coalesced callback on resume")
+
+	// Resuming again without changes does not fire.
+	w.Pause()
+	w.Resume()
+	require.Equal(t, int32(1), calls.Load())
+}
+
+func TestWatcher_SymlinkSwap_FiresCallback(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+
+	// Mimic a Kubernetes ConfigMap mount:
+	//   app.yaml -> ..data/app.yaml, ..data -> ..v1
+	writeVersion := func(version, content string) {
+		versionDir := filepath.Join(dir, version)
+		require.NoError(t, os.Mkdir(versionDir, 0o700))
+		require.NoError(t, os.WriteFile(filepath.Join(versionDir, "app.yaml"), []byte(content), 0o600))
+	}
+
+	writeVersion("..v1", "name: one")
+	require.NoError(t, os.Symlink("..v1", filepath.Join(dir, "..data")))
+
+	path := filepath.Join(dir, "app.yaml")
+	require.NoError(t, os.Symlink(filepath.Join("..data", "app.yaml"), path))
+
+	w := watcher.NewWatcher(nil)
+	defer func() { _ = w.Close() }()
+
+	called := make(chan struct{}, 4)
+	require.NoError(t, w.AddFile(path, func() { called <- struct{}{} }))
+
+	// Atomically swap the ..data symlink to a new version; the symlink path
+	// itself never changes.
+	writeVersion("..v2", "name: two")
+	require.NoError(t, os.Symlink("..v2", filepath.Join(dir, "..data_tmp")))
+	require.NoError(t, os.Rename(filepath.Join(dir, "..data_tmp"), filepath.Join(dir, "..data")))
+	require.NoError(t, os.RemoveAll(filepath.Join(dir, "..v1")))
+
+	select {
+	case <-called:
+	case <-time.After(2 * time.Second):
+		t.Fatal("callback was not called after symlink swap")
+	}
+
+	// The swap is reported once even though several events were observed.
+	select {
+	case <-called:
+		t.Fatal("symlink swap should be reported once")
+	case <-time.After(300 * time.Millisecond):
+	}
+}
+
+func TestWatcher_AddFileWithEvent_ReportsPathAndOp(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "app.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("a: 1"), 0o600))
+
+	w := watcher.NewWatcher(nil)
+	defer func() { _ = w.Close() }()
+
+	events := make(chan watcher.Event, 8)
+	require.NoError(t, w.AddFileWithEvent(path, func(event watcher.Event) { events <- event }))
+
+	next := func() watcher.Event {
+		t.Helper()
+
+		select {
+		case event := <-events:
+			return event
+		case <-time.After(2 * time.Second):
+			t.Fatal("no event received")
+
+			return watcher.Event{}
+		}
+	}
+
+	require.NoError(t, os.WriteFile(path, []byte("a: 2"), 0o600))
+
+	event := next()
+	require.Equal(t, path, event.Path)
+	require.True(t, event.Op.Has(fsnotify.Write))
+
+	require.NoError(t, os.Remove(path))
+
+	// os.WriteFile truncates before writing, so a second write event for the
+	// same save may still be queued ahead of the removal.
+	for event = next(); event.Op.Has(fsnotify.Write); event = next() {
+		require.Equal(t, path, event.Path)
+	}
+
+	require.Equal(t, path, event.Path)
+	require.True(t, event.Op.Has(fsnotify.Remove), event.Op.String())
+
+	select {
+	case extra := <-events:
+		t.Fatalf("unexpected extra event %+v", extra)
+	case <-time.After(300 * time.Millisecond):
+	}
+}
+
+func TestWatcher_AddFile_IgnoresRemoval(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "app.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("a: 1"), 0o600))
+
+	w := watcher.NewWatcher(nil)
+	defer func() { _ = w.Close() }()
+
+	called := make(chan struct{}, 1)
+	require.NoError(t, w.AddFile(path, func() { called <- struct{}{} }))
+	require.NoError(t, os.Remove(path))
+
+	select {
+	case <-called:
+		t.Fatal("plain callbacks should not run for removals")
+	case <-time.After(300 * time.Millisecond):
+	}
+}
+
+func TestWatcher_DeleteAndRecreate_ResumesCallbacks(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "app.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("a: 1"), 0o600))
+
+	w := watcher.NewWatcher(nil)
+	defer func() { _ = w.Close() }()
+
+	called := make(chan struct{}, 8)
+	require.NoError(t, w.AddFile(path, func() { called <- struct{}{} }))
+
+	expectCallback := func() {
+		t.Helper()
+
+		select {
+		case <-called:
+		case <-time.After(3 * time.Second):
+			t.Fatal("callback not fired")
+		}
+	}
+
+	// Vim-style save: the old file is deleted and a new one written in its place.
+	require.NoError(t, os.Remove(path))
+	time.Sleep(50 * time.Millisecond)
+	require.NoError(t, os.WriteFile(path, []byte("a: 2"), 0o600))
+	expectCallback()
+
+	// Let the re-added watch settle, then drain callbacks from the recreation.
+	time.Sleep(300 * time.Millisecond)
+	for len(called) > 0 {
+		<-called
+	}
+
+	require.NoError(t, os.WriteFile(path, []byte("a: 3"), 0o600))
+	expectCallback()
+}